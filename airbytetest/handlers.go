@@ -0,0 +1,1557 @@
+package airbytetest
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/evris99/airbyte-sdk/types"
+	"github.com/google/uuid"
+)
+
+// The endpoints implemented by the server
+var handlers = map[string]handler{
+	"/v1/workspaces/create":                         createWorkspace,
+	"/v1/workspaces/delete":                         deleteWorkspace,
+	"/v1/workspaces/list":                           listWorkspaces,
+	"/v1/workspaces/get":                            getWorkspace,
+	"/v1/workspaces/get_by_slug":                    getWorkspaceBySlug,
+	"/v1/workspaces/update":                         updateWorkspace,
+	"/v1/workspaces/update_name":                    updateWorkspaceName,
+	"/v1/workspaces/tag_feedback_status_as_done":    tagWorkspaceFeedback,
+	"/v1/sources/create":                            createSource,
+	"/v1/sources/update":                            updateSource,
+	"/v1/sources/list":                              listSources,
+	"/v1/sources/get":                               getSource,
+	"/v1/sources/search":                            searchSources,
+	"/v1/sources/delete":                            deleteSource,
+	"/v1/sources/check_connection":                  checkSource,
+	"/v1/sources/check_connection_for_update":       checkSource,
+	"/v1/sources/discover_schema":                   discoverSchema,
+	"/v1/destinations/create":                       createDestination,
+	"/v1/destinations/update":                       updateDestination,
+	"/v1/destinations/list":                         listDestinations,
+	"/v1/destinations/get":                          getDestination,
+	"/v1/destinations/search":                       searchDestinations,
+	"/v1/destinations/delete":                       deleteDestination,
+	"/v1/destinations/check_connection":             checkDestination,
+	"/v1/destinations/check_connection_for_update":  checkDestination,
+	"/v1/source_definitions/create":                 createSourceDefinition,
+	"/v1/source_definitions/update":                 updateSourceDefinition,
+	"/v1/source_definitions/list":                   listSourceDefinitions,
+	"/v1/source_definitions/list_latest":            listSourceDefinitions,
+	"/v1/source_definitions/get":                    getSourceDefinition,
+	"/v1/source_definitions/delete":                 deleteSourceDefinition,
+	"/v1/source_definition_specifications/get":      getSourceDefinitionSpecification,
+	"/v1/destination_definitions/create":            createDestinationDefinition,
+	"/v1/destination_definitions/update":            updateDestinationDefinition,
+	"/v1/destination_definitions/list":              listDestinationDefinitions,
+	"/v1/destination_definitions/list_latest":       listDestinationDefinitions,
+	"/v1/destination_definitions/get":               getDestinationDefinition,
+	"/v1/destination_definitions/delete":            deleteDestinationDefinition,
+	"/v1/destination_definition_specifications/get": getDestinationDefinitionSpecification,
+	"/v1/connections/create":                        createConnection,
+	"/v1/connections/update":                        updateConnection,
+	"/v1/connections/list":                          listConnections,
+	"/v1/connections/list_all":                      listAllConnections,
+	"/v1/connections/get":                           getConnection,
+	"/v1/connections/search":                        searchConnections,
+	"/v1/connections/delete":                        deleteConnection,
+	"/v1/connections/sync":                          syncConnection,
+	"/v1/connections/reset":                         resetConnection,
+	"/v1/operations/create":                         createOperation,
+	"/v1/operations/update":                         updateOperation,
+	"/v1/operations/get":                            getOperation,
+	"/v1/operations/list":                           listOperations,
+	"/v1/operations/delete":                         deleteOperation,
+	"/v1/operations/check":                          checkOperation,
+	"/v1/state/get":                                 getState,
+	"/v1/state/create_or_update":                    createOrUpdateState,
+	"/v1/web_backend/connections/get":               getWebBackendConnection,
+	"/v1/web_backend/connections/create":            createWebBackendConnection,
+	"/v1/web_backend/connections/update":            updateWebBackendConnection,
+	"/v1/web_backend/connections/list":              listWebBackendConnections,
+	"/v1/jobs/list":                                 listJobs,
+	"/v1/jobs/get":                                  getJob,
+	"/v1/jobs/cancel":                               cancelJob,
+	"/v1/health":                                    health,
+	"/v1/deployment/metadata":                       deploymentMetadata,
+}
+
+// The fields identifying resources in request bodies
+type idRequest struct {
+	WorkspaceId             *uuid.UUID `json:"workspaceId"`
+	SourceId                *uuid.UUID `json:"sourceId"`
+	DestinationId           *uuid.UUID `json:"destinationId"`
+	SourceDefinitionId      *uuid.UUID `json:"sourceDefinitionId"`
+	DestinationDefinitionId *uuid.UUID `json:"destinationDefinitionId"`
+	ConnectionId            *uuid.UUID `json:"connectionId"`
+	OperationId             *uuid.UUID `json:"operationId"`
+	WithRefreshedCatalog    bool       `json:"withRefreshedCatalog"`
+	Slug                    string     `json:"slug"`
+	Name                    string     `json:"name"`
+	DockerImageTag          string     `json:"dockerImageTag"`
+}
+
+func decodeID(body []byte) (*idRequest, error) {
+	req := new(idRequest)
+	return req, decode(body, req)
+}
+
+// Returns the value of the ID or the nil UUID
+func idOf(id *uuid.UUID) uuid.UUID {
+	if id == nil {
+		return uuid.Nil
+	}
+
+	return *id
+}
+
+func sameID(a, b *uuid.UUID) bool {
+	return idOf(a) == idOf(b)
+}
+
+func (s *Server) workspace(id *uuid.UUID) (*types.Workspace, error) {
+	workspace, ok := s.workspaces[idOf(id)]
+	if !ok {
+		return nil, notFound("Could not find workspace with id: %s", idOf(id))
+	}
+
+	return workspace, nil
+}
+
+func createWorkspace(s *Server, body []byte) (interface{}, error) {
+	workspace := new(types.Workspace)
+	if err := decode(body, workspace); err != nil {
+		return nil, err
+	}
+
+	if workspace.Name == "" {
+		return nil, invalid("name must not be empty")
+	}
+
+	id, customerID := uuid.New(), uuid.New()
+	workspace.WorkspaceId = &id
+	workspace.CustomerId = &customerID
+	workspace.Slug = s.uniqueSlug(workspace.Name)
+	s.workspaces[id] = workspace
+
+	return workspace, nil
+}
+
+// Returns a slug for the name that no other workspace uses
+func (s *Server) uniqueSlug(name string) string {
+	base := strings.Join(strings.Fields(strings.ToLower(name)), "-")
+	slug := base
+	for {
+		taken := false
+		for _, w := range s.workspaces {
+			if w.Slug == slug {
+				taken = true
+				break
+			}
+		}
+
+		if !taken {
+			return slug
+		}
+
+		slug = base + "-" + uuid.NewString()[:8]
+	}
+}
+
+func deleteWorkspace(s *Server, body []byte) (interface{}, error) {
+	req, err := decodeID(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.workspace(req.WorkspaceId); err != nil {
+		return nil, err
+	}
+
+	delete(s.workspaces, *req.WorkspaceId)
+	for id, source := range s.sources {
+		if sameID(source.WorkspaceId, req.WorkspaceId) {
+			s.deleteSource(id)
+		}
+	}
+
+	for id, dest := range s.destinations {
+		if sameID(dest.WorkspaceId, req.WorkspaceId) {
+			s.deleteDestination(id)
+		}
+	}
+
+	return nil, nil
+}
+
+func listWorkspaces(s *Server, body []byte) (interface{}, error) {
+	workspaces := make([]types.Workspace, 0, len(s.workspaces))
+	for _, w := range s.workspaces {
+		workspaces = append(workspaces, *w)
+	}
+
+	sort.Slice(workspaces, func(i, j int) bool { return workspaces[i].Name < workspaces[j].Name })
+	return map[string]interface{}{"workspaces": workspaces}, nil
+}
+
+func getWorkspace(s *Server, body []byte) (interface{}, error) {
+	req, err := decodeID(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.workspace(req.WorkspaceId)
+}
+
+func getWorkspaceBySlug(s *Server, body []byte) (interface{}, error) {
+	req, err := decodeID(body)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, w := range s.workspaces {
+		if w.Slug == req.Slug {
+			return w, nil
+		}
+	}
+
+	return nil, notFound("Could not find workspace with slug: %s", req.Slug)
+}
+
+func updateWorkspace(s *Server, body []byte) (interface{}, error) {
+	update := new(types.Workspace)
+	if err := decode(body, update); err != nil {
+		return nil, err
+	}
+
+	workspace, err := s.workspace(update.WorkspaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Email != "" {
+		workspace.Email = update.Email
+	}
+	workspace.InitialSetupComplete = update.InitialSetupComplete
+	workspace.DisplaySetupWizard = update.DisplaySetupWizard
+	workspace.AnonymousDataCollection = update.AnonymousDataCollection
+	workspace.News = update.News
+	workspace.SecurityUpdates = update.SecurityUpdates
+	workspace.Notifications = update.Notifications
+
+	return workspace, nil
+}
+
+func updateWorkspaceName(s *Server, body []byte) (interface{}, error) {
+	req, err := decodeID(body)
+	if err != nil {
+		return nil, err
+	}
+
+	workspace, err := s.workspace(req.WorkspaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name == "" {
+		return nil, invalid("name must not be empty")
+	}
+
+	workspace.Name = req.Name
+	return workspace, nil
+}
+
+func tagWorkspaceFeedback(s *Server, body []byte) (interface{}, error) {
+	req, err := decodeID(body)
+	if err != nil {
+		return nil, err
+	}
+
+	workspace, err := s.workspace(req.WorkspaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	workspace.FeedbackDone = true
+	return nil, nil
+}
+
+func (s *Server) source(id *uuid.UUID) (*types.Source, error) {
+	source, ok := s.sources[idOf(id)]
+	if !ok {
+		return nil, notFound("Could not find source with id: %s", idOf(id))
+	}
+
+	return source, nil
+}
+
+func createSource(s *Server, body []byte) (interface{}, error) {
+	source := new(types.Source)
+	if err := decode(body, source); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.workspace(source.WorkspaceId); err != nil {
+		return nil, err
+	}
+
+	def, ok := s.sourceDefinitions[idOf(source.SourceDefinitionId)]
+	if !ok {
+		return nil, notFound("Could not find source definition with id: %s", idOf(source.SourceDefinitionId))
+	}
+
+	if source.Name == "" {
+		return nil, invalid("name must not be empty")
+	}
+
+	id := uuid.New()
+	source.SourceId = &id
+	source.SourceName = def.Name
+	s.sources[id] = source
+
+	return source, nil
+}
+
+func updateSource(s *Server, body []byte) (interface{}, error) {
+	update := new(types.Source)
+	if err := decode(body, update); err != nil {
+		return nil, err
+	}
+
+	source, err := s.source(update.SourceId)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Name != "" {
+		source.Name = update.Name
+	}
+	source.ConnectionConfiguration = update.ConnectionConfiguration
+
+	return source, nil
+}
+
+func listSources(s *Server, body []byte) (interface{}, error) {
+	req, err := decodeID(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.workspace(req.WorkspaceId); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"sources": s.findSources(&types.Source{WorkspaceId: req.WorkspaceId})}, nil
+}
+
+func getSource(s *Server, body []byte) (interface{}, error) {
+	req, err := decodeID(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.source(req.SourceId)
+}
+
+func searchSources(s *Server, body []byte) (interface{}, error) {
+	search := new(types.Source)
+	if err := decode(body, search); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"sources": s.findSources(search)}, nil
+}
+
+// Returns the sources matching the set fields of the search, sorted by name
+func (s *Server) findSources(search *types.Source) []types.Source {
+	sources := make([]types.Source, 0)
+	for _, source := range s.sources {
+		if (search.SourceId == nil || sameID(search.SourceId, source.SourceId)) &&
+			(search.WorkspaceId == nil || sameID(search.WorkspaceId, source.WorkspaceId)) &&
+			(search.SourceDefinitionId == nil || sameID(search.SourceDefinitionId, source.SourceDefinitionId)) &&
+			(search.Name == "" || search.Name == source.Name) {
+			sources = append(sources, *source)
+		}
+	}
+
+	sort.Slice(sources, func(i, j int) bool { return sources[i].Name < sources[j].Name })
+	return sources
+}
+
+func deleteSource(s *Server, body []byte) (interface{}, error) {
+	req, err := decodeID(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.source(req.SourceId); err != nil {
+		return nil, err
+	}
+
+	s.deleteSource(*req.SourceId)
+	return nil, nil
+}
+
+// Deletes the source and deprecates its connections
+func (s *Server) deleteSource(id uuid.UUID) {
+	delete(s.sources, id)
+	for _, conn := range s.connections {
+		if idOf(conn.SourceID) == id {
+			conn.Status = types.Deprecated
+		}
+	}
+}
+
+func checkSource(s *Server, body []byte) (interface{}, error) {
+	req, err := decodeID(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.source(req.SourceId); err != nil {
+		return nil, err
+	}
+
+	return s.connectionCheck(types.CheckConnectionSource, req.SourceId), nil
+}
+
+func discoverSchema(s *Server, body []byte) (interface{}, error) {
+	req, err := decodeID(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.source(req.SourceId); err != nil {
+		return nil, err
+	}
+
+	if s.failedDiscoveries[*req.SourceId] {
+		jobInfo := s.jobInfo(types.DiscoverSchema, req.SourceId)
+		jobInfo.Succeeded = false
+		return &types.SourceDiscoverSchema{JobInfo: jobInfo}, nil
+	}
+
+	catalog := new(types.SyncCatalogType)
+	if c, ok := s.catalogs[*req.SourceId]; ok {
+		clone(c, catalog)
+	}
+
+	catalogID := uuid.New()
+	return &types.SourceDiscoverSchema{
+		Catalog:   catalog,
+		CatalogId: &catalogID,
+		JobInfo:   s.jobInfo(types.DiscoverSchema, req.SourceId),
+	}, nil
+}
+
+func (s *Server) connectionCheck(configType types.ConfigTypeEnum, id *uuid.UUID) *types.ConnectionCheck {
+	return &types.ConnectionCheck{
+		Status:  types.Succeeded,
+		JobInfo: s.jobInfo(configType, id),
+	}
+}
+
+// Returns the information of a synchronous job that succeeded immediately
+func (s *Server) jobInfo(configType types.ConfigTypeEnum, configID *uuid.UUID) *types.JobInfo {
+	id := uuid.New()
+	now := int(time.Now().Unix())
+
+	return &types.JobInfo{
+		ID:         &id,
+		ConfigType: configType,
+		ConfigId:   idOf(configID).String(),
+		CreatedAt:  now,
+		EndedAt:    now,
+		Succeeded:  true,
+	}
+}
+
+func (s *Server) destination(id *uuid.UUID) (*types.Destination, error) {
+	dest, ok := s.destinations[idOf(id)]
+	if !ok {
+		return nil, notFound("Could not find destination with id: %s", idOf(id))
+	}
+
+	return dest, nil
+}
+
+func createDestination(s *Server, body []byte) (interface{}, error) {
+	dest := new(types.Destination)
+	if err := decode(body, dest); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.workspace(dest.WorkspaceId); err != nil {
+		return nil, err
+	}
+
+	def, ok := s.destinationDefinitions[idOf(dest.DestinationDefinitionId)]
+	if !ok {
+		return nil, notFound("Could not find destination definition with id: %s", idOf(dest.DestinationDefinitionId))
+	}
+
+	if dest.Name == "" {
+		return nil, invalid("name must not be empty")
+	}
+
+	id := uuid.New()
+	dest.DestinationId = &id
+	dest.DestinationName = def.Name
+	s.destinations[id] = dest
+
+	return dest, nil
+}
+
+func updateDestination(s *Server, body []byte) (interface{}, error) {
+	update := new(types.Destination)
+	if err := decode(body, update); err != nil {
+		return nil, err
+	}
+
+	dest, err := s.destination(update.DestinationId)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Name != "" {
+		dest.Name = update.Name
+	}
+	dest.ConnectionConfiguration = update.ConnectionConfiguration
+
+	return dest, nil
+}
+
+func listDestinations(s *Server, body []byte) (interface{}, error) {
+	req, err := decodeID(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.workspace(req.WorkspaceId); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"destinations": s.findDestinations(&types.Destination{WorkspaceId: req.WorkspaceId})}, nil
+}
+
+func getDestination(s *Server, body []byte) (interface{}, error) {
+	req, err := decodeID(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.destination(req.DestinationId)
+}
+
+func searchDestinations(s *Server, body []byte) (interface{}, error) {
+	search := new(types.Destination)
+	if err := decode(body, search); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"destinations": s.findDestinations(search)}, nil
+}
+
+// Returns the destinations matching the set fields of the search, sorted by name
+func (s *Server) findDestinations(search *types.Destination) []types.Destination {
+	destinations := make([]types.Destination, 0)
+	for _, dest := range s.destinations {
+		if (search.DestinationId == nil || sameID(search.DestinationId, dest.DestinationId)) &&
+			(search.WorkspaceId == nil || sameID(search.WorkspaceId, dest.WorkspaceId)) &&
+			(search.DestinationDefinitionId == nil || sameID(search.DestinationDefinitionId, dest.DestinationDefinitionId)) &&
+			(search.Name == "" || search.Name == dest.Name) {
+			destinations = append(destinations, *dest)
+		}
+	}
+
+	sort.Slice(destinations, func(i, j int) bool { return destinations[i].Name < destinations[j].Name })
+	return destinations
+}
+
+func deleteDestination(s *Server, body []byte) (interface{}, error) {
+	req, err := decodeID(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.destination(req.DestinationId); err != nil {
+		return nil, err
+	}
+
+	s.deleteDestination(*req.DestinationId)
+	return nil, nil
+}
+
+// Deletes the destination and deprecates its connections
+func (s *Server) deleteDestination(id uuid.UUID) {
+	delete(s.destinations, id)
+	for _, conn := range s.connections {
+		if idOf(conn.DestinationId) == id {
+			conn.Status = types.Deprecated
+		}
+	}
+}
+
+func checkDestination(s *Server, body []byte) (interface{}, error) {
+	req, err := decodeID(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.destination(req.DestinationId); err != nil {
+		return nil, err
+	}
+
+	return s.connectionCheck(types.CheckConnectionDestination, req.DestinationId), nil
+}
+
+func (s *Server) addSourceDefinition(def *types.SourceDefinition) uuid.UUID {
+	id := uuid.New()
+	def.SourceDefinitionId = &id
+	s.sourceDefinitions[id] = def
+	return id
+}
+
+func (s *Server) sourceDefinition(id *uuid.UUID) (*types.SourceDefinition, error) {
+	def, ok := s.sourceDefinitions[idOf(id)]
+	if !ok {
+		return nil, notFound("Could not find source definition with id: %s", idOf(id))
+	}
+
+	return def, nil
+}
+
+func createSourceDefinition(s *Server, body []byte) (interface{}, error) {
+	def := new(types.SourceDefinition)
+	if err := decode(body, def); err != nil {
+		return nil, err
+	}
+
+	if err := validateDefinition(&def.Definition); err != nil {
+		return nil, err
+	}
+
+	s.addSourceDefinition(def)
+	return def, nil
+}
+
+func updateSourceDefinition(s *Server, body []byte) (interface{}, error) {
+	req, err := decodeID(body)
+	if err != nil {
+		return nil, err
+	}
+
+	def, err := s.sourceDefinition(req.SourceDefinitionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.DockerImageTag != "" {
+		def.DockerImageTag = req.DockerImageTag
+	}
+
+	return def, nil
+}
+
+func listSourceDefinitions(s *Server, body []byte) (interface{}, error) {
+	defs := make([]types.SourceDefinition, 0, len(s.sourceDefinitions))
+	for _, def := range s.sourceDefinitions {
+		defs = append(defs, *def)
+	}
+
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Name < defs[j].Name })
+	return map[string]interface{}{"sourceDefinitions": defs}, nil
+}
+
+func getSourceDefinition(s *Server, body []byte) (interface{}, error) {
+	req, err := decodeID(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.sourceDefinition(req.SourceDefinitionId)
+}
+
+func deleteSourceDefinition(s *Server, body []byte) (interface{}, error) {
+	req, err := decodeID(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.sourceDefinition(req.SourceDefinitionId); err != nil {
+		return nil, err
+	}
+
+	delete(s.sourceDefinitions, *req.SourceDefinitionId)
+	delete(s.sourceSpecs, *req.SourceDefinitionId)
+	return nil, nil
+}
+
+func getSourceDefinitionSpecification(s *Server, body []byte) (interface{}, error) {
+	req, err := decodeID(body)
+	if err != nil {
+		return nil, err
+	}
+
+	def, err := s.sourceDefinition(req.SourceDefinitionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if spec, ok := s.sourceSpecs[*def.SourceDefinitionId]; ok {
+		return spec, nil
+	}
+
+	spec := &types.SourceDefinitionSpecification{SourceDefinitionId: def.SourceDefinitionId}
+	spec.DocumentationUrl = def.DocumentationURL
+	spec.ConnectionSpecification = emptySpecification()
+	return spec, nil
+}
+
+func (s *Server) addDestinationDefinition(def *types.DestinationDefinition) uuid.UUID {
+	id := uuid.New()
+	def.DestinationDefinitionId = &id
+	s.destinationDefinitions[id] = def
+	return id
+}
+
+func (s *Server) destinationDefinition(id *uuid.UUID) (*types.DestinationDefinition, error) {
+	def, ok := s.destinationDefinitions[idOf(id)]
+	if !ok {
+		return nil, notFound("Could not find destination definition with id: %s", idOf(id))
+	}
+
+	return def, nil
+}
+
+func createDestinationDefinition(s *Server, body []byte) (interface{}, error) {
+	def := new(types.DestinationDefinition)
+	if err := decode(body, def); err != nil {
+		return nil, err
+	}
+
+	if err := validateDefinition(&def.Definition); err != nil {
+		return nil, err
+	}
+
+	s.addDestinationDefinition(def)
+	return def, nil
+}
+
+func updateDestinationDefinition(s *Server, body []byte) (interface{}, error) {
+	req, err := decodeID(body)
+	if err != nil {
+		return nil, err
+	}
+
+	def, err := s.destinationDefinition(req.DestinationDefinitionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.DockerImageTag != "" {
+		def.DockerImageTag = req.DockerImageTag
+	}
+
+	return def, nil
+}
+
+func listDestinationDefinitions(s *Server, body []byte) (interface{}, error) {
+	defs := make([]types.DestinationDefinition, 0, len(s.destinationDefinitions))
+	for _, def := range s.destinationDefinitions {
+		defs = append(defs, *def)
+	}
+
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Name < defs[j].Name })
+	return map[string]interface{}{"destinationDefinitions": defs}, nil
+}
+
+func getDestinationDefinition(s *Server, body []byte) (interface{}, error) {
+	req, err := decodeID(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.destinationDefinition(req.DestinationDefinitionId)
+}
+
+func deleteDestinationDefinition(s *Server, body []byte) (interface{}, error) {
+	req, err := decodeID(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.destinationDefinition(req.DestinationDefinitionId); err != nil {
+		return nil, err
+	}
+
+	delete(s.destinationDefinitions, *req.DestinationDefinitionId)
+	delete(s.destinationSpecs, *req.DestinationDefinitionId)
+	return nil, nil
+}
+
+func getDestinationDefinitionSpecification(s *Server, body []byte) (interface{}, error) {
+	req, err := decodeID(body)
+	if err != nil {
+		return nil, err
+	}
+
+	def, err := s.destinationDefinition(req.DestinationDefinitionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if spec, ok := s.destinationSpecs[*def.DestinationDefinitionId]; ok {
+		return spec, nil
+	}
+
+	spec := &types.DestinationDefinitionSpecification{DestinationDefinitionId: def.DestinationDefinitionId}
+	spec.DocumentationUrl = def.DocumentationURL
+	spec.ConnectionSpecification = emptySpecification()
+	spec.SupportedDestinationSyncModes = []types.SupportedDestinationSyncModesType{types.Overwrite, types.Append}
+	return spec, nil
+}
+
+func validateDefinition(def *types.Definition) error {
+	switch {
+	case def.Name == "":
+		return invalid("name must not be empty")
+	case def.DockerRepository == "":
+		return invalid("dockerRepository must not be empty")
+	case def.DockerImageTag == "":
+		return invalid("dockerImageTag must not be empty")
+	}
+
+	return nil
+}
+
+// Returns a connector specification without any properties
+func emptySpecification() map[string]interface{} {
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{},
+	}
+}
+
+func (s *Server) connection(id *uuid.UUID) (*types.Connection, error) {
+	conn, ok := s.connections[idOf(id)]
+	if !ok {
+		return nil, notFound("Could not find connection with id: %s", idOf(id))
+	}
+
+	return conn, nil
+}
+
+func createConnection(s *Server, body []byte) (interface{}, error) {
+	conn := new(types.Connection)
+	if err := decode(body, conn); err != nil {
+		return nil, err
+	}
+
+	if err := s.addConnection(conn); err != nil {
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// Validates and stores a new connection
+func (s *Server) addConnection(conn *types.Connection) error {
+	if _, err := s.source(conn.SourceID); err != nil {
+		return err
+	}
+
+	if _, err := s.destination(conn.DestinationId); err != nil {
+		return err
+	}
+
+	if conn.Status == "" {
+		return invalid("status must not be empty")
+	}
+
+	if conn.SyncCatalog == nil {
+		conn.SyncCatalog = new(types.SyncCatalogType)
+	}
+
+	id := uuid.New()
+	conn.ConnectionId = &id
+	s.connections[id] = conn
+
+	return nil
+}
+
+func updateConnection(s *Server, body []byte) (interface{}, error) {
+	update := new(types.Connection)
+	if err := decode(body, update); err != nil {
+		return nil, err
+	}
+
+	return s.updateConnection(update)
+}
+
+// Applies the update to the stored connection and returns it
+func (s *Server) updateConnection(update *types.Connection) (*types.Connection, error) {
+	conn, err := s.connection(update.ConnectionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Name != "" {
+		conn.Name = update.Name
+	}
+
+	if update.Status != "" {
+		conn.Status = update.Status
+	}
+
+	if update.SyncCatalog != nil {
+		conn.SyncCatalog = update.SyncCatalog
+	}
+
+	if update.NamespaceDefinition != "" {
+		conn.NamespaceDefinition = update.NamespaceDefinition
+	}
+
+	conn.NamespaceFormat = update.NamespaceFormat
+	conn.Prefix = update.Prefix
+	conn.OperationIds = update.OperationIds
+	conn.Schedule = update.Schedule
+	conn.ResourceRequirements = update.ResourceRequirements
+
+	return conn, nil
+}
+
+func listConnections(s *Server, body []byte) (interface{}, error) {
+	return s.listConnections(body, false)
+}
+
+func listAllConnections(s *Server, body []byte) (interface{}, error) {
+	return s.listConnections(body, true)
+}
+
+// Lists the connections of the workspace in the body
+func (s *Server) listConnections(body []byte, includeDeleted bool) (interface{}, error) {
+	req, err := decodeID(body)
+	if err != nil {
+		return nil, err
+	}
+
+	connections, err := s.workspaceConnections(req.WorkspaceId, includeDeleted)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"connections": connections}, nil
+}
+
+// Returns the connections of the workspace, sorted by name
+func (s *Server) workspaceConnections(workspaceID *uuid.UUID, includeDeleted bool) ([]*types.Connection, error) {
+	if _, err := s.workspace(workspaceID); err != nil {
+		return nil, err
+	}
+
+	connections := make([]*types.Connection, 0)
+	for _, conn := range s.connections {
+		if !includeDeleted && conn.Status == types.Deprecated {
+			continue
+		}
+
+		if s.connectionWorkspace(conn) == idOf(workspaceID) {
+			connections = append(connections, conn)
+		}
+	}
+
+	sort.Slice(connections, func(i, j int) bool { return connections[i].Name < connections[j].Name })
+	return connections, nil
+}
+
+// Returns the ID of the workspace the connection belongs to
+func (s *Server) connectionWorkspace(conn *types.Connection) uuid.UUID {
+	if source, ok := s.sources[idOf(conn.SourceID)]; ok {
+		return idOf(source.WorkspaceId)
+	}
+
+	if dest, ok := s.destinations[idOf(conn.DestinationId)]; ok {
+		return idOf(dest.WorkspaceId)
+	}
+
+	return uuid.Nil
+}
+
+func getConnection(s *Server, body []byte) (interface{}, error) {
+	req, err := decodeID(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.connection(req.ConnectionId)
+}
+
+func searchConnections(s *Server, body []byte) (interface{}, error) {
+	search := new(types.Connection)
+	if err := decode(body, search); err != nil {
+		return nil, err
+	}
+
+	connections := make([]types.Connection, 0)
+	for _, conn := range s.connections {
+		if (search.ConnectionId == nil || sameID(search.ConnectionId, conn.ConnectionId)) &&
+			(search.SourceID == nil || sameID(search.SourceID, conn.SourceID)) &&
+			(search.DestinationId == nil || sameID(search.DestinationId, conn.DestinationId)) &&
+			(search.Name == "" || search.Name == conn.Name) &&
+			(search.Status == "" || search.Status == conn.Status) {
+			connections = append(connections, *conn)
+		}
+	}
+
+	sort.Slice(connections, func(i, j int) bool { return connections[i].Name < connections[j].Name })
+	return map[string]interface{}{"connections": connections}, nil
+}
+
+func deleteConnection(s *Server, body []byte) (interface{}, error) {
+	req, err := decodeID(body)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := s.connection(req.ConnectionId)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.Status = types.Deprecated
+	return nil, nil
+}
+
+func syncConnection(s *Server, body []byte) (interface{}, error) {
+	return s.startJob(body, types.Sync)
+}
+
+func resetConnection(s *Server, body []byte) (interface{}, error) {
+	return s.startJob(body, types.ResetConnection)
+}
+
+// Starts a running job of the given type for the connection in the body
+func (s *Server) startJob(body []byte, configType types.ConfigTypeEnum) (interface{}, error) {
+	req, err := decodeID(body)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := s.connection(req.ConnectionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if conn.Status != types.Active {
+		return nil, &apiError{status: http.StatusConflict, message: "connection is not active"}
+	}
+
+	now := time.Now().Unix()
+	s.lastJobID++
+	job := &types.JobDetails{
+		Job: &types.Job{
+			ID:         s.lastJobID,
+			ConfigType: configType,
+			ConfigId:   conn.ConnectionId.String(),
+			CreatedAt:  now,
+			UpdatedAt:  now,
+			Status:     types.JobRunning,
+		},
+		Attempts: []types.AttemptInfo{{
+			Attempt: &types.Attempt{Status: types.AttemptRunning, CreatedAt: now, UpdatedAt: now},
+			Logs:    &types.Logs{LogLines: []string{}},
+		}},
+	}
+	s.jobs[job.Job.ID] = job
+
+	return job, nil
+}
+
+// The body of job requests
+type jobRequest struct {
+	ID          int64                  `json:"id"`
+	ConfigTypes []types.ConfigTypeEnum `json:"configTypes"`
+	ConfigId    string                 `json:"configId"`
+	Pagination  *struct {
+		PageSize  int `json:"pageSize"`
+		RowOffset int `json:"rowOffset"`
+	} `json:"pagination"`
+}
+
+func (s *Server) job(body []byte) (*types.JobDetails, error) {
+	req := new(jobRequest)
+	if err := decode(body, req); err != nil {
+		return nil, err
+	}
+
+	job, ok := s.jobs[req.ID]
+	if !ok {
+		return nil, notFound("Could not find job with id: %d", req.ID)
+	}
+
+	return job, nil
+}
+
+func getJob(s *Server, body []byte) (interface{}, error) {
+	return s.job(body)
+}
+
+func cancelJob(s *Server, body []byte) (interface{}, error) {
+	job, err := s.job(body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch job.Job.Status {
+	case types.JobSucceeded, types.JobFailed, types.JobCancelled:
+		return nil, &apiError{status: http.StatusConflict, message: "job is already finished"}
+	}
+
+	job.Job.Status = types.JobCancelled
+	job.Job.UpdatedAt = time.Now().Unix()
+	return job, nil
+}
+
+func listJobs(s *Server, body []byte) (interface{}, error) {
+	req := new(jobRequest)
+	if err := decode(body, req); err != nil {
+		return nil, err
+	}
+
+	jobs := make([]types.JobWithAttempts, 0)
+	for _, job := range s.jobs {
+		if job.Job.ConfigId != req.ConfigId || !hasConfigType(req.ConfigTypes, job.Job.ConfigType) {
+			continue
+		}
+
+		withAttempts := types.JobWithAttempts{Job: job.Job}
+		for _, attempt := range job.Attempts {
+			withAttempts.Attempts = append(withAttempts.Attempts, *attempt.Attempt)
+		}
+		jobs = append(jobs, withAttempts)
+	}
+
+	// The most recent jobs come first
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].Job.ID > jobs[j].Job.ID })
+
+	if p := req.Pagination; p != nil && p.PageSize > 0 {
+		start, end := p.RowOffset, p.RowOffset+p.PageSize
+		if start > len(jobs) {
+			start = len(jobs)
+		}
+
+		if end > len(jobs) {
+			end = len(jobs)
+		}
+		jobs = jobs[start:end]
+	}
+
+	return map[string]interface{}{"jobs": jobs}, nil
+}
+
+func hasConfigType(configTypes []types.ConfigTypeEnum, configType types.ConfigTypeEnum) bool {
+	for _, t := range configTypes {
+		if t == configType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Server) operation(id *uuid.UUID) (*types.Operation, error) {
+	operation, ok := s.operations[idOf(id)]
+	if !ok {
+		return nil, notFound("Could not find operation with id: %s", idOf(id))
+	}
+
+	return operation, nil
+}
+
+func createOperation(s *Server, body []byte) (interface{}, error) {
+	operation := new(types.Operation)
+	if err := decode(body, operation); err != nil {
+		return nil, err
+	}
+
+	if err := s.addOperation(operation); err != nil {
+		return nil, err
+	}
+
+	return operation, nil
+}
+
+// Validates and stores a new operation
+func (s *Server) addOperation(operation *types.Operation) error {
+	if _, err := s.workspace(operation.WorkspaceId); err != nil {
+		return err
+	}
+
+	if operation.Name == "" {
+		return invalid("name must not be empty")
+	}
+
+	if msg := validateOperator(operation.OperatorConfiguration); msg != "" {
+		return invalid("%s", msg)
+	}
+
+	id := uuid.New()
+	operation.OperationId = &id
+	s.operations[id] = operation
+
+	return nil
+}
+
+func updateOperation(s *Server, body []byte) (interface{}, error) {
+	update := new(types.Operation)
+	if err := decode(body, update); err != nil {
+		return nil, err
+	}
+
+	return s.updateOperation(update)
+}
+
+// Applies the update to the stored operation and returns it
+func (s *Server) updateOperation(update *types.Operation) (*types.Operation, error) {
+	operation, err := s.operation(update.OperationId)
+	if err != nil {
+		return nil, err
+	}
+
+	if msg := validateOperator(update.OperatorConfiguration); msg != "" {
+		return nil, invalid("%s", msg)
+	}
+
+	if update.Name != "" {
+		operation.Name = update.Name
+	}
+	operation.OperatorConfiguration = update.OperatorConfiguration
+
+	return operation, nil
+}
+
+func getOperation(s *Server, body []byte) (interface{}, error) {
+	req, err := decodeID(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.operation(req.OperationId)
+}
+
+func listOperations(s *Server, body []byte) (interface{}, error) {
+	req, err := decodeID(body)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := s.connection(req.ConnectionId)
+	if err != nil {
+		return nil, err
+	}
+
+	operations := make([]types.Operation, 0, len(conn.OperationIds))
+	for _, id := range conn.OperationIds {
+		if operation, ok := s.operations[id]; ok {
+			operations = append(operations, *operation)
+		}
+	}
+
+	return map[string]interface{}{"operations": operations}, nil
+}
+
+func deleteOperation(s *Server, body []byte) (interface{}, error) {
+	req, err := decodeID(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.operation(req.OperationId); err != nil {
+		return nil, err
+	}
+
+	delete(s.operations, *req.OperationId)
+
+	// Detach the operation from its connections
+	for _, conn := range s.connections {
+		ids := conn.OperationIds[:0]
+		for _, id := range conn.OperationIds {
+			if id != *req.OperationId {
+				ids = append(ids, id)
+			}
+		}
+		conn.OperationIds = ids
+	}
+
+	return nil, nil
+}
+
+func checkOperation(s *Server, body []byte) (interface{}, error) {
+	config := new(types.OperatorConfiguration)
+	if err := decode(body, config); err != nil {
+		return nil, err
+	}
+
+	if msg := validateOperator(config); msg != "" {
+		return &types.OperationCheck{Status: types.Failed, Message: msg}, nil
+	}
+
+	return &types.OperationCheck{Status: types.Succeeded}, nil
+}
+
+// Returns a message describing what is wrong with the operator configuration, if anything
+func validateOperator(config *types.OperatorConfiguration) string {
+	if config == nil {
+		return "operatorConfiguration must not be empty"
+	}
+
+	switch config.OperatorType {
+	case types.Normalization:
+		if config.Normalization == nil || config.Normalization.Option == "" {
+			return "normalization option must not be empty"
+		}
+	case types.Dbt:
+		if config.Dbt == nil || config.Dbt.GitRepoUrl == "" {
+			return "dbt gitRepoUrl must not be empty"
+		}
+	case "":
+		return "operatorType must not be empty"
+	}
+
+	return ""
+}
+
+func getState(s *Server, body []byte) (interface{}, error) {
+	req, err := decodeID(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.connection(req.ConnectionId); err != nil {
+		return nil, err
+	}
+
+	if state, ok := s.states[*req.ConnectionId]; ok {
+		return state, nil
+	}
+
+	return &types.ConnectionState{StateType: types.NotSetStateType, ConnectionId: req.ConnectionId}, nil
+}
+
+func createOrUpdateState(s *Server, body []byte) (interface{}, error) {
+	var req struct {
+		ConnectionId    *uuid.UUID             `json:"connectionId"`
+		ConnectionState *types.ConnectionState `json:"connectionState"`
+	}
+
+	// Keep the precision of cursor values
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&req); err != nil {
+		return nil, invalid("invalid request body: %v", err)
+	}
+
+	if _, err := s.connection(req.ConnectionId); err != nil {
+		return nil, err
+	}
+
+	if req.ConnectionState == nil || req.ConnectionState.StateType == "" {
+		return nil, invalid("connectionState.stateType must not be empty")
+	}
+
+	req.ConnectionState.ConnectionId = req.ConnectionId
+	s.states[*req.ConnectionId] = req.ConnectionState
+	return req.ConnectionState, nil
+}
+
+func getWebBackendConnection(s *Server, body []byte) (interface{}, error) {
+	req, err := decodeID(body)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := s.connection(req.ConnectionId)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.webBackendConnection(conn, req.WithRefreshedCatalog), nil
+}
+
+func createWebBackendConnection(s *Server, body []byte) (interface{}, error) {
+	create := new(types.WebBackendConnectionCreate)
+	if err := decode(body, create); err != nil {
+		return nil, err
+	}
+
+	source, err := s.source(create.SourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := s.saveOperations(source.WorkspaceId, create.Operations)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := &create.Connection
+	conn.OperationIds = append(conn.OperationIds, ids...)
+	if err := s.addConnection(conn); err != nil {
+		return nil, err
+	}
+
+	return s.webBackendConnection(conn, false), nil
+}
+
+func updateWebBackendConnection(s *Server, body []byte) (interface{}, error) {
+	update := new(types.WebBackendConnectionUpdate)
+	if err := decode(body, update); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.connection(update.ConnectionId)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := s.source(existing.SourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := s.saveOperations(source.WorkspaceId, update.Operations)
+	if err != nil {
+		return nil, err
+	}
+
+	update.OperationIds = ids
+	conn, err := s.updateConnection(&update.Connection)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.webBackendConnection(conn, false), nil
+}
+
+func listWebBackendConnections(s *Server, body []byte) (interface{}, error) {
+	req, err := decodeID(body)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := s.workspaceConnections(req.WorkspaceId, false)
+	if err != nil {
+		return nil, err
+	}
+
+	connections := make([]*types.WebBackendConnection, 0, len(list))
+	for _, conn := range list {
+		connections = append(connections, s.webBackendConnection(conn, false))
+	}
+
+	return map[string]interface{}{"connections": connections}, nil
+}
+
+// Creates the operations without an ID and updates the rest. Returns the IDs of all the operations
+func (s *Server) saveOperations(workspaceID *uuid.UUID, operations []types.Operation) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(operations))
+	for i := range operations {
+		operation := operations[i]
+		if operation.OperationId == nil {
+			operation.WorkspaceId = workspaceID
+			if err := s.addOperation(&operation); err != nil {
+				return nil, err
+			}
+		} else if _, err := s.updateOperation(&operation); err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, *operation.OperationId)
+	}
+
+	return ids, nil
+}
+
+// Returns the connection along with its source, destination, operations and latest sync job
+func (s *Server) webBackendConnection(conn *types.Connection, withRefreshedCatalog bool) *types.WebBackendConnection {
+	read := &types.WebBackendConnection{
+		Source:      s.sources[idOf(conn.SourceID)],
+		Destination: s.destinations[idOf(conn.DestinationId)],
+		Operations:  make([]types.Operation, 0, len(conn.OperationIds)),
+	}
+	clone(conn, &read.Connection)
+
+	for _, id := range conn.OperationIds {
+		if operation, ok := s.operations[id]; ok {
+			read.Operations = append(read.Operations, *operation)
+		}
+	}
+
+	var latest *types.Job
+	for _, job := range s.jobs {
+		if job.Job.ConfigType == types.Sync && job.Job.ConfigId == conn.ConnectionId.String() && (latest == nil || job.Job.ID > latest.ID) {
+			latest = job.Job
+		}
+	}
+
+	if latest != nil {
+		read.LatestSyncJobCreatedAt = latest.CreatedAt
+		read.LatestSyncJobStatus = latest.Status
+		read.IsSyncing = latest.Status == types.JobRunning || latest.Status == types.JobPending
+	}
+
+	if withRefreshedCatalog {
+		catalogID := uuid.New()
+		read.CatalogId = &catalogID
+		read.CatalogDiff = &types.CatalogDiff{Transforms: make([]types.StreamTransform, 0)}
+		if discovered, ok := s.catalogs[idOf(conn.SourceID)]; ok {
+			read.CatalogDiff.Transforms = streamTransforms(conn.SyncCatalog, discovered)
+		}
+	}
+
+	return read
+}
+
+// Returns the streams added to and removed from the current catalog
+func streamTransforms(current, discovered *types.SyncCatalogType) []types.StreamTransform {
+	transforms := make([]types.StreamTransform, 0)
+	for _, s := range discovered.Streams {
+		if current.Find(s.Stream.Name, s.Stream.Namespace) == nil {
+			transforms = append(transforms, types.StreamTransform{
+				TransformType:    types.AddStream,
+				StreamDescriptor: &types.StreamDescriptor{Name: s.Stream.Name, Namespace: s.Stream.Namespace},
+			})
+		}
+	}
+
+	for _, s := range current.Streams {
+		if discovered.Find(s.Stream.Name, s.Stream.Namespace) == nil {
+			transforms = append(transforms, types.StreamTransform{
+				TransformType:    types.RemoveStream,
+				StreamDescriptor: &types.StreamDescriptor{Name: s.Stream.Name, Namespace: s.Stream.Namespace},
+			})
+		}
+	}
+
+	return transforms
+}
+
+func health(s *Server, body []byte) (interface{}, error) {
+	return &types.HealthCheck{Available: true}, nil
+}
+
+func deploymentMetadata(s *Server, body []byte) (interface{}, error) {
+	id := uuid.NewSHA1(uuid.NameSpaceURL, []byte(s.URL))
+	return &types.DeploymentMetadata{
+		ID:            &id,
+		Mode:          "OSS",
+		Version:       s.version,
+		DeploymentEnv: "DOCKER",
+	}, nil
+}
@@ -0,0 +1,336 @@
+// Package airbytetest provides an in-memory fake of the airbyte API for tests.
+//
+// The server keeps workspaces, sources, destinations, definitions, connections,
+// operations and jobs in memory and responds with the same error responses as airbyte
+// when a resource does not exist or a request is invalid. Failures can be
+// injected for any endpoint to test how code handles an unhealthy server.
+package airbytetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/evris99/airbyte-sdk/types"
+	"github.com/google/uuid"
+)
+
+// The path under which the API is served
+const apiPath = "/api"
+
+// The version reported by a new server
+const DefaultVersion = "0.40.18"
+
+// The endpoints served with the GET method. All the others are served with POST
+var getEndpoints = map[string]bool{
+	"/v1/health": true,
+}
+
+// A Failure is an error response returned instead of the normal response of an endpoint
+type Failure struct {
+	// The HTTP status code of the response
+	Status int
+	// The message of the error response
+	Message string
+	// The number of requests that fail. Zero means all the following requests fail
+	Times int
+}
+
+// A Server is a fake airbyte API server backed by memory
+type Server struct {
+	*httptest.Server
+
+	mu                     sync.Mutex
+	workspaces             map[uuid.UUID]*types.Workspace
+	sources                map[uuid.UUID]*types.Source
+	destinations           map[uuid.UUID]*types.Destination
+	sourceDefinitions      map[uuid.UUID]*types.SourceDefinition
+	destinationDefinitions map[uuid.UUID]*types.DestinationDefinition
+	sourceSpecs            map[uuid.UUID]*types.SourceDefinitionSpecification
+	destinationSpecs       map[uuid.UUID]*types.DestinationDefinitionSpecification
+	catalogs               map[uuid.UUID]*types.SyncCatalogType
+	failedDiscoveries      map[uuid.UUID]bool
+	connections            map[uuid.UUID]*types.Connection
+	operations             map[uuid.UUID]*types.Operation
+	states                 map[uuid.UUID]*types.ConnectionState
+	jobs                   map[int64]*types.JobDetails
+	lastJobID              int64
+	failures               map[string]*Failure
+	requests               map[string]int
+	version                string
+}
+
+// NewServer starts and returns a new server.
+// It is seeded with the PokeAPI source definition and the Local JSON destination definition.
+// The caller should call Close when finished
+func NewServer() *Server {
+	s := &Server{
+		workspaces:             make(map[uuid.UUID]*types.Workspace),
+		sources:                make(map[uuid.UUID]*types.Source),
+		destinations:           make(map[uuid.UUID]*types.Destination),
+		sourceDefinitions:      make(map[uuid.UUID]*types.SourceDefinition),
+		destinationDefinitions: make(map[uuid.UUID]*types.DestinationDefinition),
+		sourceSpecs:            make(map[uuid.UUID]*types.SourceDefinitionSpecification),
+		destinationSpecs:       make(map[uuid.UUID]*types.DestinationDefinitionSpecification),
+		catalogs:               make(map[uuid.UUID]*types.SyncCatalogType),
+		failedDiscoveries:      make(map[uuid.UUID]bool),
+		connections:            make(map[uuid.UUID]*types.Connection),
+		operations:             make(map[uuid.UUID]*types.Operation),
+		states:                 make(map[uuid.UUID]*types.ConnectionState),
+		jobs:                   make(map[int64]*types.JobDetails),
+		failures:               make(map[string]*Failure),
+		requests:               make(map[string]int),
+		version:                DefaultVersion,
+	}
+
+	s.AddSourceDefinition(&types.SourceDefinition{
+		Definition: types.Definition{
+			Name:             "PokeAPI",
+			DockerRepository: "airbyte/source-pokeapi",
+			DockerImageTag:   "0.1.5",
+			ReleaseStage:     types.Alpha,
+		},
+	})
+
+	s.AddDestinationDefinition(&types.DestinationDefinition{
+		Definition: types.Definition{
+			Name:             "Local JSON",
+			DockerRepository: "airbyte/destination-local-json",
+			DockerImageTag:   "0.2.11",
+			ReleaseStage:     types.Alpha,
+		},
+	})
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Endpoint returns the API URL to create a client with
+func (s *Server) Endpoint() string {
+	return s.URL + apiPath
+}
+
+// InjectFailure makes the endpoint with the given path, for example /v1/sources/create, fail
+func (s *Server) InjectFailure(path string, failure Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures[path] = &failure
+}
+
+// ClearFailures removes all the injected failures
+func (s *Server) ClearFailures() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = make(map[string]*Failure)
+}
+
+// Requests returns the number of requests made to the endpoint with the given path
+func (s *Server) Requests(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests[path]
+}
+
+// AddSourceDefinition adds a source definition and returns its ID
+func (s *Server) AddSourceDefinition(def *types.SourceDefinition) uuid.UUID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addSourceDefinition(def)
+}
+
+// AddDestinationDefinition adds a destination definition and returns its ID
+func (s *Server) AddDestinationDefinition(def *types.DestinationDefinition) uuid.UUID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addDestinationDefinition(def)
+}
+
+// SetSourceDefinitionSpecification sets the specification returned for the source definition with the given ID
+func (s *Server) SetSourceDefinitionSpecification(id uuid.UUID, spec *types.SourceDefinitionSpecification) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	spec.SourceDefinitionId = &id
+	s.sourceSpecs[id] = spec
+}
+
+// SetDestinationDefinitionSpecification sets the specification returned for the destination definition with the given ID
+func (s *Server) SetDestinationDefinitionSpecification(id uuid.UUID, spec *types.DestinationDefinitionSpecification) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	spec.DestinationDefinitionId = &id
+	s.destinationSpecs[id] = spec
+}
+
+// SetCatalog sets the catalog discovered for the source with the given ID
+func (s *Server) SetCatalog(sourceID uuid.UUID, catalog *types.SyncCatalogType) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.catalogs[sourceID] = catalog
+}
+
+// FailDiscovery makes the schema discovery of the source with the given ID fail,
+// returning a job that did not succeed and no catalog
+func (s *Server) FailDiscovery(sourceID uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failedDiscoveries[sourceID] = true
+}
+
+// SetVersion sets the version reported in the deployment metadata
+func (s *Server) SetVersion(version string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.version = version
+}
+
+// FinishJob sets the status of the job with the given ID and of its last attempt
+func (s *Server) FinishJob(id int64, status types.JobStatus, failure *types.AttemptFailureSummary) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return fmt.Errorf("job %d does not exist", id)
+	}
+
+	job.Job.Status = status
+	if last := job.Attempts[len(job.Attempts)-1].Attempt; last != nil {
+		last.Status = types.AttemptFailed
+		if status == types.JobSucceeded {
+			last.Status = types.AttemptSucceeded
+		}
+		last.FailureSummary = failure
+	}
+
+	return nil
+}
+
+// An error returned by an endpoint handler
+type apiError struct {
+	status  int
+	message string
+}
+
+func (e *apiError) Error() string {
+	return e.message
+}
+
+func notFound(format string, args ...interface{}) error {
+	return &apiError{status: http.StatusNotFound, message: fmt.Sprintf(format, args...)}
+}
+
+func invalid(format string, args ...interface{}) error {
+	return &apiError{status: http.StatusUnprocessableEntity, message: fmt.Sprintf(format, args...)}
+}
+
+// An endpoint handler receives the request body and returns the response body
+type handler func(s *Server, body []byte) (interface{}, error)
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, apiPath)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests[path]++
+
+	if failure, ok := s.failures[path]; ok {
+		if failure.Times > 0 {
+			failure.Times--
+			if failure.Times == 0 {
+				delete(s.failures, path)
+			}
+		}
+
+		writeError(w, failure.Status, failure.Message)
+		return
+	}
+
+	h, ok := handlers[path]
+	method := http.MethodPost
+	if getEndpoints[path] {
+		method = http.MethodGet
+	}
+
+	if !ok || r.Method != method {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("unknown endpoint %s %s", r.Method, path))
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response, err := h(s, body)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if e, ok := err.(*apiError); ok {
+			status = e.status
+		}
+
+		writeError(w, status, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if response == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	json.NewEncoder(w).Encode(response)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	if message == "" {
+		message = http.StatusText(status)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(&types.ResponseError{
+		ID:      uuid.NewString(),
+		Message: message,
+	})
+}
+
+// Decodes the request body into v
+func decode(body []byte, v interface{}) error {
+	if len(body) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return &apiError{status: http.StatusBadRequest, message: fmt.Sprintf("invalid request body: %v", err)}
+	}
+
+	return nil
+}
+
+// Deep copies src into dst so stored resources are not shared
+func clone(src, dst interface{}) {
+	data, err := json.Marshal(src)
+	if err != nil {
+		panic(err)
+	}
+
+	if err := json.Unmarshal(data, dst); err != nil {
+		panic(err)
+	}
+}
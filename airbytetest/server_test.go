@@ -0,0 +1,120 @@
+package airbytetest_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	airbytesdk "github.com/evris99/airbyte-sdk"
+	"github.com/evris99/airbyte-sdk/airbytetest"
+	"github.com/evris99/airbyte-sdk/types"
+	"github.com/google/uuid"
+)
+
+func TestInjectFailure(t *testing.T) {
+	srv := airbytetest.NewServer()
+	defer srv.Close()
+
+	client, err := airbytesdk.New(srv.Endpoint())
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	srv.InjectFailure("/v1/workspaces/list", airbytetest.Failure{Status: http.StatusServiceUnavailable, Times: 1})
+
+	if _, err := client.ListWorkspaces(context.Background()); !airbytesdk.IsServerError(err) {
+		t.Fatalf("expected a server error, got %v", err)
+	}
+
+	if _, err := client.ListWorkspaces(context.Background()); err != nil {
+		t.Fatalf("expected the failure to be injected once, got %v", err)
+	}
+
+	if n := srv.Requests("/v1/workspaces/list"); n != 2 {
+		t.Fatalf("expected 2 requests, got %d", n)
+	}
+}
+
+func TestNotFound(t *testing.T) {
+	srv := airbytetest.NewServer()
+	defer srv.Close()
+
+	client, err := airbytesdk.New(srv.Endpoint())
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	id := uuid.New()
+	if _, err := client.GetConnection(context.Background(), &id); !airbytesdk.IsNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+
+	workspace, err := client.CreateWorkspace(context.Background(), &types.Workspace{})
+	if !airbytesdk.IsValidation(err) {
+		t.Fatalf("expected a validation error, got %v and %v", workspace, err)
+	}
+}
+
+func TestSyncJob(t *testing.T) {
+	srv := airbytetest.NewServer()
+	defer srv.Close()
+
+	client, err := airbytesdk.New(srv.Endpoint())
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	ctx := context.Background()
+	workspace, err := client.CreateWorkspace(ctx, &types.Workspace{Name: "test"})
+	if err != nil {
+		t.Fatalf("could not create workspace: %v", err)
+	}
+
+	sourceDefs, err := client.ListSourceDefinitions(ctx)
+	if err != nil {
+		t.Fatalf("could not list source definitions: %v", err)
+	}
+
+	destDefs, err := client.ListDestinationDefinitions(ctx)
+	if err != nil {
+		t.Fatalf("could not list destination definitions: %v", err)
+	}
+
+	source, err := client.CreateSource(ctx, &types.Source{Name: "source", WorkspaceId: workspace.WorkspaceId, SourceDefinitionId: sourceDefs[0].SourceDefinitionId})
+	if err != nil {
+		t.Fatalf("could not create source: %v", err)
+	}
+
+	dest, err := client.CreateDestination(ctx, &types.Destination{Name: "dest", WorkspaceId: workspace.WorkspaceId, DestinationDefinitionId: destDefs[0].DestinationDefinitionId})
+	if err != nil {
+		t.Fatalf("could not create destination: %v", err)
+	}
+
+	conn, err := client.CreateConnection(ctx, &types.Connection{SourceID: source.SourceId, DestinationId: dest.DestinationId, Status: types.Active})
+	if err != nil {
+		t.Fatalf("could not create connection: %v", err)
+	}
+
+	job, err := client.SyncConnection(ctx, conn.ConnectionId)
+	if err != nil {
+		t.Fatalf("could not sync connection: %v", err)
+	}
+
+	if err := srv.FinishJob(job.Job.ID, types.JobSucceeded, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := client.WaitForJob(ctx, job.Job.ID, nil)
+	if err != nil {
+		t.Fatalf("could not wait for job: %v", err)
+	}
+
+	if !result.Succeeded() {
+		t.Fatalf("expected the job to succeed, got %s", result.Status)
+	}
+
+	jobs, err := client.ListJobs(ctx, []types.ConfigTypeEnum{types.Sync}, conn.ConnectionId.String())
+	if err != nil || len(jobs) != 1 {
+		t.Fatalf("expected 1 job, got %v and %v", jobs, err)
+	}
+}
@@ -0,0 +1,157 @@
+package airbytesdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A CredentialsProvider authenticates the requests made by the client.
+// It is called before every request and may refresh its credentials when needed
+type CredentialsProvider interface {
+	SetCredentials(ctx context.Context, req *http.Request) error
+}
+
+// BasicAuth authenticates requests with a static username and password
+type BasicAuth struct {
+	Username string
+	Password string
+}
+
+// SetCredentials sets the basic authorization header of the request
+func (b BasicAuth) SetCredentials(ctx context.Context, req *http.Request) error {
+	req.SetBasicAuth(b.Username, b.Password)
+	return nil
+}
+
+// BearerToken authenticates requests with a static bearer token
+type BearerToken string
+
+// SetCredentials sets the bearer authorization header of the request
+func (t BearerToken) SetCredentials(ctx context.Context, req *http.Request) error {
+	req.Header.Set("Authorization", "Bearer "+string(t))
+	return nil
+}
+
+// The time before the expiration of a token at which it is refreshed
+const tokenExpiryMargin = 30 * time.Second
+
+// ClientCredentials authenticates requests with bearer tokens obtained
+// using the OAuth 2.0 client credentials grant. Tokens are cached and
+// requested again shortly before they expire
+type ClientCredentials struct {
+	// The URL of the token endpoint
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	// Optional scopes to request
+	Scopes []string
+	// The HTTP client used to request tokens. Defaults to http.DefaultClient
+	HttpClient *http.Client
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// NewClientCredentials returns a provider that requests tokens from the given token URL
+func NewClientCredentials(tokenURL, clientID, clientSecret string) *ClientCredentials {
+	return &ClientCredentials{
+		TokenURL:     tokenURL,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+	}
+}
+
+// SetCredentials sets the bearer authorization header of the request, requesting a new token if needed
+func (cc *ClientCredentials) SetCredentials(ctx context.Context, req *http.Request) error {
+	token, err := cc.Token(ctx)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// Token returns a valid access token, requesting a new one if the cached token expired
+func (cc *ClientCredentials) Token(ctx context.Context) (string, error) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	if cc.token != "" && (cc.expiry.IsZero() || time.Now().Before(cc.expiry.Add(-tokenExpiryMargin))) {
+		return cc.token, nil
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("client_id", cc.ClientID)
+	form.Set("client_secret", cc.ClientSecret)
+	if len(cc.Scopes) > 0 {
+		form.Set("scope", strings.Join(cc.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", cc.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("could not create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	client := cc.HttpClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("could not request token: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("could not request token: %s", res.Status)
+	}
+
+	var body struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("could not decode token response: %w", err)
+	}
+
+	if body.AccessToken == "" {
+		return "", fmt.Errorf("token response did not contain an access token")
+	}
+
+	cc.token = body.AccessToken
+	cc.expiry = time.Time{}
+	if body.ExpiresIn > 0 {
+		cc.expiry = time.Now().Add(time.Duration(body.ExpiresIn) * time.Second)
+	}
+
+	return cc.token, nil
+}
+
+// WithBasicAuth authenticates every request with the given username and password
+func WithBasicAuth(username, password string) Option {
+	return WithCredentialsProvider(BasicAuth{Username: username, Password: password})
+}
+
+// WithBearerToken authenticates every request with the given bearer token
+func WithBearerToken(token string) Option {
+	return WithCredentialsProvider(BearerToken(token))
+}
+
+// WithCredentialsProvider authenticates every request with the given provider
+func WithCredentialsProvider(provider CredentialsProvider) Option {
+	return func(c *Client) {
+		c.credentials = provider
+	}
+}
@@ -0,0 +1,71 @@
+package airbytesdk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBasicAuth(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "airbyte" || password != "password" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Write([]byte(`{"workspaces":[]}`))
+	}))
+	defer srv.Close()
+
+	airbyte, err := New(srv.URL+"/api", WithBasicAuth("airbyte", "password"))
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	if _, err := airbyte.ListWorkspaces(context.Background()); err != nil {
+		t.Fatalf("could not list workspaces: %v", err)
+	}
+}
+
+func TestClientCredentials(t *testing.T) {
+	tokenRequests := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests++
+		if r.FormValue("grant_type") != "client_credentials" || r.FormValue("client_id") != "id" || r.FormValue("client_secret") != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Write([]byte(`{"access_token":"abc","token_type":"Bearer","expires_in":3600}`))
+	})
+	mux.HandleFunc("/api/v1/workspaces/list", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer abc" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Write([]byte(`{"workspaces":[]}`))
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	provider := NewClientCredentials(srv.URL+"/token", "id", "secret")
+	airbyte, err := New(srv.URL+"/api", WithCredentialsProvider(provider))
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := airbyte.ListWorkspaces(context.Background()); err != nil {
+			t.Fatalf("could not list workspaces: %v", err)
+		}
+	}
+
+	if tokenRequests != 1 {
+		t.Fatalf("expected the token to be cached, got %d token requests", tokenRequests)
+	}
+}
@@ -0,0 +1,407 @@
+package airbytesdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/evris99/airbyte-sdk/types"
+	"github.com/google/uuid"
+)
+
+// The version of the bundles created by ExportWorkspace
+const WorkspaceBundleVersion = 1
+
+var (
+	ErrUnsupportedBundleVersion = errors.New("unsupported workspace bundle version")
+	ErrMissingSecrets           = errors.New("redacted secrets were not supplied")
+	ErrDefinitionNotFound       = errors.New("connector definition not found")
+)
+
+// A portable copy of the resources of a workspace, created by ExportWorkspace.
+// The IDs are the ones of the exported workspace and are remapped on import
+type WorkspaceBundle struct {
+	Version      int                 `json:"version"`
+	ExportedAt   time.Time           `json:"exportedAt"`
+	Workspace    types.Workspace     `json:"workspace"`
+	Sources      []BundleSource      `json:"sources"`
+	Destinations []BundleDestination `json:"destinations"`
+	Operations   []types.Operation   `json:"operations"`
+	Connections  []types.Connection  `json:"connections"`
+}
+
+// A source of a bundle
+type BundleSource struct {
+	types.Source
+	// The dot separated paths of the configuration fields holding redacted secrets
+	RedactedSecrets []string `json:"redactedSecrets,omitempty"`
+}
+
+// A destination of a bundle
+type BundleDestination struct {
+	types.Destination
+	// The dot separated paths of the configuration fields holding redacted secrets
+	RedactedSecrets []string `json:"redactedSecrets,omitempty"`
+}
+
+// Options for importing a workspace bundle
+type ImportOptions struct {
+	// The secrets of the sources by source name.
+	// Each map holds the values of the redacted fields by their dot separated path, such as tunnels.0.password
+	SourceSecrets map[string]map[string]interface{}
+	// The secrets of the destinations by destination name.
+	// Each map holds the values of the redacted fields by their dot separated path, such as tunnels.0.password
+	DestinationSecrets map[string]map[string]interface{}
+}
+
+// WorkspaceBundleFromJSON reads json data from a Reader and returns a workspace bundle.
+// Bundles of unsupported versions return ErrUnsupportedBundleVersion
+func WorkspaceBundleFromJSON(r io.Reader) (*WorkspaceBundle, error) {
+	bundle := new(WorkspaceBundle)
+	if err := json.NewDecoder(r).Decode(bundle); err != nil {
+		return nil, err
+	}
+
+	if bundle.Version != WorkspaceBundleVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedBundleVersion, bundle.Version)
+	}
+
+	return bundle, nil
+}
+
+// ExportWorkspace returns a bundle with the sources, destinations, connections and operations of the workspace.
+// Secrets of the connector configurations are redacted
+func (c *Client) ExportWorkspace(ctx context.Context, id *uuid.UUID) (*WorkspaceBundle, error) {
+	workspace, err := c.FindWorkspaceByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("could not get workspace: %w", err)
+	}
+
+	bundle := &WorkspaceBundle{
+		Version:    WorkspaceBundleVersion,
+		ExportedAt: time.Now().UTC(),
+		Workspace:  *workspace,
+	}
+
+	sources, err := c.ListWorkspaceSources(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("could not list sources: %w", err)
+	}
+
+	sourceSpecs := make(map[uuid.UUID]map[string]interface{})
+	for _, source := range sources {
+		spec, ok := sourceSpecs[idOrNil(source.SourceDefinitionId)]
+		if !ok {
+			definitionSpec, err := c.GetSourceDefinitionSpecification(ctx, source.SourceDefinitionId)
+			if err != nil {
+				return nil, fmt.Errorf("could not get specification of source %q: %w", source.Name, err)
+			}
+
+			spec = definitionSpec.ConnectionSpecification
+			sourceSpecs[idOrNil(source.SourceDefinitionId)] = spec
+		}
+
+		exported := BundleSource{Source: source}
+		exported.ConnectionConfiguration, exported.RedactedSecrets = redactConfiguration(source.ConnectionConfiguration, spec)
+		bundle.Sources = append(bundle.Sources, exported)
+	}
+
+	destinations, err := c.ListWorkspaceDestinations(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("could not list destinations: %w", err)
+	}
+
+	destinationSpecs := make(map[uuid.UUID]map[string]interface{})
+	for _, destination := range destinations {
+		spec, ok := destinationSpecs[idOrNil(destination.DestinationDefinitionId)]
+		if !ok {
+			definitionSpec, err := c.GetDestinationDefinitionSpecification(ctx, destination.DestinationDefinitionId)
+			if err != nil {
+				return nil, fmt.Errorf("could not get specification of destination %q: %w", destination.Name, err)
+			}
+
+			spec = definitionSpec.ConnectionSpecification
+			destinationSpecs[idOrNil(destination.DestinationDefinitionId)] = spec
+		}
+
+		exported := BundleDestination{Destination: destination}
+		exported.ConnectionConfiguration, exported.RedactedSecrets = redactConfiguration(destination.ConnectionConfiguration, spec)
+		bundle.Destinations = append(bundle.Destinations, exported)
+	}
+
+	bundle.Connections, err = c.ListWorkspaceConnections(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("could not list connections: %w", err)
+	}
+
+	// Operations can only be listed through the connections they are attached to
+	seen := make(map[uuid.UUID]bool)
+	for _, conn := range bundle.Connections {
+		operations, err := c.ListOperations(ctx, conn.ConnectionId)
+		if err != nil {
+			return nil, fmt.Errorf("could not list operations of connection %q: %w", conn.Name, err)
+		}
+
+		for _, operation := range operations {
+			if operationID := idOrNil(operation.OperationId); !seen[operationID] {
+				seen[operationID] = true
+				bundle.Operations = append(bundle.Operations, operation)
+			}
+		}
+	}
+
+	return bundle, nil
+}
+
+// ImportWorkspace creates the resources of the bundle in the target workspace and returns
+// the IDs of the created resources by their IDs in the bundle.
+// Redacted secrets must be supplied in the options, otherwise ErrMissingSecrets is returned before anything is created.
+// Connector definitions are matched by ID and then by name.
+// If creating a resource fails, the IDs of the resources created so far are returned with the error
+func (c *Client) ImportWorkspace(ctx context.Context, bundle *WorkspaceBundle, targetWorkspace *uuid.UUID, opts *ImportOptions) (map[uuid.UUID]uuid.UUID, error) {
+	if bundle.Version != WorkspaceBundleVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedBundleVersion, bundle.Version)
+	}
+
+	if opts == nil {
+		opts = &ImportOptions{}
+	}
+
+	sources, destinations, err := restoreSecrets(bundle, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceDefinitions, destinationDefinitions, err := c.resolveBundleDefinitions(ctx, sources, destinations)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[uuid.UUID]uuid.UUID)
+	for _, source := range sources {
+		created, err := c.CreateSource(ctx, &types.Source{
+			Name:                    source.Name,
+			WorkspaceId:             targetWorkspace,
+			SourceDefinitionId:      sourceDefinitions[idOrNil(source.SourceDefinitionId)],
+			ConnectionConfiguration: source.ConnectionConfiguration,
+		})
+		if err != nil {
+			return ids, fmt.Errorf("could not create source %q: %w", source.Name, err)
+		}
+
+		ids[idOrNil(source.SourceId)] = idOrNil(created.SourceId)
+	}
+
+	for _, destination := range destinations {
+		created, err := c.CreateDestination(ctx, &types.Destination{
+			Name:                    destination.Name,
+			WorkspaceId:             targetWorkspace,
+			DestinationDefinitionId: destinationDefinitions[idOrNil(destination.DestinationDefinitionId)],
+			ConnectionConfiguration: destination.ConnectionConfiguration,
+		})
+		if err != nil {
+			return ids, fmt.Errorf("could not create destination %q: %w", destination.Name, err)
+		}
+
+		ids[idOrNil(destination.DestinationId)] = idOrNil(created.DestinationId)
+	}
+
+	for _, operation := range bundle.Operations {
+		created, err := c.CreateOperation(ctx, &types.Operation{
+			Name:                  operation.Name,
+			WorkspaceId:           targetWorkspace,
+			OperatorConfiguration: operation.OperatorConfiguration,
+		})
+		if err != nil {
+			return ids, fmt.Errorf("could not create operation %q: %w", operation.Name, err)
+		}
+
+		ids[idOrNil(operation.OperationId)] = idOrNil(created.OperationId)
+	}
+
+	for _, conn := range bundle.Connections {
+		sourceID, ok := ids[idOrNil(conn.SourceID)]
+		if !ok {
+			return ids, fmt.Errorf("could not create connection %q: source %s is not in the bundle", conn.Name, idOrNil(conn.SourceID))
+		}
+
+		destinationID, ok := ids[idOrNil(conn.DestinationId)]
+		if !ok {
+			return ids, fmt.Errorf("could not create connection %q: destination %s is not in the bundle", conn.Name, idOrNil(conn.DestinationId))
+		}
+
+		imported := conn
+		imported.ConnectionId = nil
+		imported.SourceID = &sourceID
+		imported.DestinationId = &destinationID
+		imported.OperationIds = nil
+		for _, operationID := range conn.OperationIds {
+			newID, ok := ids[operationID]
+			if !ok {
+				return ids, fmt.Errorf("could not create connection %q: operation %s is not in the bundle", conn.Name, operationID)
+			}
+			imported.OperationIds = append(imported.OperationIds, newID)
+		}
+
+		created, err := c.CreateConnection(ctx, &imported)
+		if err != nil {
+			return ids, fmt.Errorf("could not create connection %q: %w", conn.Name, err)
+		}
+
+		ids[idOrNil(conn.ConnectionId)] = idOrNil(created.ConnectionId)
+	}
+
+	return ids, nil
+}
+
+// Returns copies of the connectors of the bundle with the supplied secrets in place of the redacted ones
+func restoreSecrets(bundle *WorkspaceBundle, opts *ImportOptions) ([]BundleSource, []BundleDestination, error) {
+	var missing []string
+
+	sources := make([]BundleSource, len(bundle.Sources))
+	for i, source := range bundle.Sources {
+		config, paths := fillSecrets(source.ConnectionConfiguration, source.RedactedSecrets, opts.SourceSecrets[source.Name])
+		for _, path := range paths {
+			missing = append(missing, fmt.Sprintf("source %q: %s", source.Name, path))
+		}
+
+		sources[i] = source
+		sources[i].ConnectionConfiguration = config
+	}
+
+	destinations := make([]BundleDestination, len(bundle.Destinations))
+	for i, destination := range bundle.Destinations {
+		config, paths := fillSecrets(destination.ConnectionConfiguration, destination.RedactedSecrets, opts.DestinationSecrets[destination.Name])
+		for _, path := range paths {
+			missing = append(missing, fmt.Sprintf("destination %q: %s", destination.Name, path))
+		}
+
+		destinations[i] = destination
+		destinations[i].ConnectionConfiguration = config
+	}
+
+	if len(missing) > 0 {
+		return nil, nil, fmt.Errorf("%w: %s", ErrMissingSecrets, strings.Join(missing, ", "))
+	}
+
+	return sources, destinations, nil
+}
+
+// Returns a copy of the configuration with the redacted paths set to the given secrets and the paths without a secret
+func fillSecrets(config map[string]interface{}, redacted []string, secrets map[string]interface{}) (map[string]interface{}, []string) {
+	filled := copyConfiguration(config)
+
+	var missing []string
+	for _, path := range redacted {
+		secret, ok := secrets[path]
+		if !ok {
+			missing = append(missing, path)
+			continue
+		}
+
+		setConfigurationValue(filled, path, secret)
+	}
+
+	return filled, missing
+}
+
+// Maps the definition IDs of the connectors of the bundle to the IDs of the same definitions in the server
+func (c *Client) resolveBundleDefinitions(ctx context.Context, sources []BundleSource, destinations []BundleDestination) (map[uuid.UUID]*uuid.UUID, map[uuid.UUID]*uuid.UUID, error) {
+	sourceDefinitions := make(map[uuid.UUID]*uuid.UUID)
+	if len(sources) > 0 {
+		definitions, err := c.ListSourceDefinitions(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not list source definitions: %w", err)
+		}
+
+		for _, source := range sources {
+			var byID, byName *uuid.UUID
+			for i := range definitions {
+				if idOrNil(definitions[i].SourceDefinitionId) == idOrNil(source.SourceDefinitionId) {
+					byID = definitions[i].SourceDefinitionId
+				} else if definitions[i].Name == source.SourceName && byName == nil {
+					byName = definitions[i].SourceDefinitionId
+				}
+			}
+
+			if byID == nil && byName == nil {
+				return nil, nil, fmt.Errorf("%w: source %q uses %q", ErrDefinitionNotFound, source.Name, source.SourceName)
+			}
+
+			if byID != nil {
+				sourceDefinitions[idOrNil(source.SourceDefinitionId)] = byID
+			} else {
+				sourceDefinitions[idOrNil(source.SourceDefinitionId)] = byName
+			}
+		}
+	}
+
+	destinationDefinitions := make(map[uuid.UUID]*uuid.UUID)
+	if len(destinations) > 0 {
+		definitions, err := c.ListDestinationDefinitions(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not list destination definitions: %w", err)
+		}
+
+		for _, destination := range destinations {
+			var byID, byName *uuid.UUID
+			for i := range definitions {
+				if idOrNil(definitions[i].DestinationDefinitionId) == idOrNil(destination.DestinationDefinitionId) {
+					byID = definitions[i].DestinationDefinitionId
+				} else if definitions[i].Name == destination.DestinationName && byName == nil {
+					byName = definitions[i].DestinationDefinitionId
+				}
+			}
+
+			if byID == nil && byName == nil {
+				return nil, nil, fmt.Errorf("%w: destination %q uses %q", ErrDefinitionNotFound, destination.Name, destination.DestinationName)
+			}
+
+			if byID != nil {
+				destinationDefinitions[idOrNil(destination.DestinationDefinitionId)] = byID
+			} else {
+				destinationDefinitions[idOrNil(destination.DestinationDefinitionId)] = byName
+			}
+		}
+	}
+
+	return sourceDefinitions, destinationDefinitions, nil
+}
+
+// Returns a deep copy of a connector configuration
+func copyConfiguration(config map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(config))
+	for key, value := range config {
+		copied[key] = copyValue(value)
+	}
+
+	return copied
+}
+
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return copyConfiguration(v)
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i := range v {
+			copied[i] = copyValue(v[i])
+		}
+
+		return copied
+	}
+
+	return value
+}
+
+func idOrNil(id *uuid.UUID) uuid.UUID {
+	if id == nil {
+		return uuid.Nil
+	}
+
+	return *id
+}
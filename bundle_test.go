@@ -0,0 +1,222 @@
+package airbytesdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/evris99/airbyte-sdk/airbytetest"
+	"github.com/evris99/airbyte-sdk/types"
+)
+
+func TestExportImportWorkspace(t *testing.T) {
+	srv := airbytetest.NewServer()
+	defer srv.Close()
+
+	airbyte, err := New(srv.Endpoint())
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	ctx := context.Background()
+	conn := createTestConnection(t, airbyte)
+	source, err := airbyte.GetSource(ctx, conn.SourceID)
+	if err != nil {
+		t.Fatalf("could not get source: %v", err)
+	}
+
+	srv.SetSourceDefinitionSpecification(*source.SourceDefinitionId, &types.SourceDefinitionSpecification{
+		DefinitionSpecification: types.DefinitionSpecification{ConnectionSpecification: map[string]interface{}{
+			"properties": map[string]interface{}{
+				"api_key": map[string]interface{}{"type": "string", "airbyte_secret": true},
+			},
+		}},
+	})
+
+	source.ConnectionConfiguration = map[string]interface{}{"pokemon_name": "snorlax", "api_key": "secret"}
+	if _, err := airbyte.UpdateSource(ctx, source); err != nil {
+		t.Fatalf("could not update source: %v", err)
+	}
+
+	operation, err := airbyte.CreateOperation(ctx, &types.Operation{
+		Name:        "normalize",
+		WorkspaceId: source.WorkspaceId,
+		OperatorConfiguration: &types.OperatorConfiguration{
+			OperatorType:  types.Normalization,
+			Normalization: &types.OperatorNormalization{Option: types.BasicNormalization},
+		},
+	})
+	if err != nil {
+		t.Fatalf("could not create operation: %v", err)
+	}
+
+	conn.OperationIds = append(conn.OperationIds, *operation.OperationId)
+	conn.Schedule = &types.Schedule{Units: 6, TimeUnit: types.Hours}
+	if _, err := airbyte.UpdateConnection(ctx, conn); err != nil {
+		t.Fatalf("could not update connection: %v", err)
+	}
+
+	bundle, err := airbyte.ExportWorkspace(ctx, source.WorkspaceId)
+	if err != nil {
+		t.Fatalf("could not export workspace: %v", err)
+	}
+
+	if len(bundle.Sources) != 1 || len(bundle.Destinations) != 1 || len(bundle.Connections) != 1 || len(bundle.Operations) != 1 {
+		t.Fatalf("unexpected bundle: %+v", bundle)
+	}
+
+	if bundle.Sources[0].ConnectionConfiguration["api_key"] != RedactedSecret {
+		t.Fatal("the secret of the source was not redacted")
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(bundle); err != nil {
+		t.Fatalf("could not encode bundle: %v", err)
+	}
+
+	bundle, err = WorkspaceBundleFromJSON(&buf)
+	if err != nil {
+		t.Fatalf("could not decode bundle: %v", err)
+	}
+
+	target, err := airbyte.CreateWorkspace(ctx, &types.Workspace{Name: "production"})
+	if err != nil {
+		t.Fatalf("could not create workspace: %v", err)
+	}
+
+	_, err = airbyte.ImportWorkspace(ctx, bundle, target.WorkspaceId, nil)
+	if !errors.Is(err, ErrMissingSecrets) || !strings.Contains(err.Error(), "api_key") {
+		t.Fatalf("expected missing secrets error, got: %v", err)
+	}
+
+	ids, err := airbyte.ImportWorkspace(ctx, bundle, target.WorkspaceId, &ImportOptions{
+		SourceSecrets: map[string]map[string]interface{}{"source": {"api_key": "production-secret"}},
+	})
+	if err != nil {
+		t.Fatalf("could not import workspace: %v", err)
+	}
+
+	if len(ids) != 4 {
+		t.Fatalf("expected 4 remapped IDs, got %v", ids)
+	}
+
+	connections, err := airbyte.ListWorkspaceConnections(ctx, target.WorkspaceId)
+	if err != nil || len(connections) != 1 {
+		t.Fatalf("could not list imported connections: %v", err)
+	}
+
+	imported := connections[0]
+	if *imported.SourceID != ids[*conn.SourceID] || *imported.DestinationId != ids[*conn.DestinationId] {
+		t.Fatalf("connectors of the connection were not remapped: %+v", imported)
+	}
+
+	if len(imported.OperationIds) != 1 || imported.OperationIds[0] != ids[*operation.OperationId] {
+		t.Fatalf("operations of the connection were not remapped: %v", imported.OperationIds)
+	}
+
+	if imported.Schedule == nil || imported.Schedule.Units != 6 {
+		t.Fatalf("schedule was not imported: %+v", imported.Schedule)
+	}
+
+	importedSource, err := airbyte.GetSource(ctx, imported.SourceID)
+	if err != nil {
+		t.Fatalf("could not get imported source: %v", err)
+	}
+
+	if importedSource.ConnectionConfiguration["api_key"] != "production-secret" || importedSource.ConnectionConfiguration["pokemon_name"] != "snorlax" {
+		t.Fatalf("unexpected imported configuration: %v", importedSource.ConnectionConfiguration)
+	}
+}
+
+func TestExportImportArraySecrets(t *testing.T) {
+	srv := airbytetest.NewServer()
+	defer srv.Close()
+
+	airbyte, err := New(srv.Endpoint())
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	ctx := context.Background()
+	conn := createTestConnection(t, airbyte)
+	source, err := airbyte.GetSource(ctx, conn.SourceID)
+	if err != nil {
+		t.Fatalf("could not get source: %v", err)
+	}
+
+	srv.SetSourceDefinitionSpecification(*source.SourceDefinitionId, &types.SourceDefinitionSpecification{
+		DefinitionSpecification: types.DefinitionSpecification{ConnectionSpecification: map[string]interface{}{
+			"properties": map[string]interface{}{
+				"tunnels": map[string]interface{}{
+					"type": "array",
+					"items": map[string]interface{}{"properties": map[string]interface{}{
+						"password": map[string]interface{}{"type": "string", "airbyte_secret": true},
+					}},
+				},
+			},
+		}},
+	})
+
+	source.ConnectionConfiguration = map[string]interface{}{
+		"pokemon_name": "snorlax",
+		"tunnels": []interface{}{
+			map[string]interface{}{"host": "a.internal", "password": "first"},
+			map[string]interface{}{"host": "b.internal", "password": "second"},
+		},
+	}
+	if _, err := airbyte.UpdateSource(ctx, source); err != nil {
+		t.Fatalf("could not update source: %v", err)
+	}
+
+	bundle, err := airbyte.ExportWorkspace(ctx, source.WorkspaceId)
+	if err != nil {
+		t.Fatalf("could not export workspace: %v", err)
+	}
+
+	if redacted := bundle.Sources[0].RedactedSecrets; strings.Join(redacted, ",") != "tunnels.0.password,tunnels.1.password" {
+		t.Fatalf("unexpected redacted secrets %v", redacted)
+	}
+
+	target, err := airbyte.CreateWorkspace(ctx, &types.Workspace{Name: "production"})
+	if err != nil {
+		t.Fatalf("could not create workspace: %v", err)
+	}
+
+	ids, err := airbyte.ImportWorkspace(ctx, bundle, target.WorkspaceId, &ImportOptions{
+		SourceSecrets: map[string]map[string]interface{}{"source": {"tunnels.0.password": "new-first", "tunnels.1.password": "new-second"}},
+	})
+	if err != nil {
+		t.Fatalf("could not import workspace: %v", err)
+	}
+
+	sourceID := ids[*source.SourceId]
+	imported, err := airbyte.GetSource(ctx, &sourceID)
+	if err != nil {
+		t.Fatalf("could not get imported source: %v", err)
+	}
+
+	tunnels, ok := imported.ConnectionConfiguration["tunnels"].([]interface{})
+	if !ok || len(tunnels) != 2 {
+		t.Fatalf("the tunnels were not imported as an array: %v", imported.ConnectionConfiguration)
+	}
+
+	for i, want := range []map[string]interface{}{
+		{"host": "a.internal", "password": "new-first"},
+		{"host": "b.internal", "password": "new-second"},
+	} {
+		tunnel, _ := tunnels[i].(map[string]interface{})
+		if tunnel["host"] != want["host"] || tunnel["password"] != want["password"] {
+			t.Errorf("unexpected tunnel %d: %v", i, tunnels[i])
+		}
+	}
+}
+
+func TestWorkspaceBundleVersion(t *testing.T) {
+	_, err := WorkspaceBundleFromJSON(strings.NewReader(`{"version": 99}`))
+	if !errors.Is(err, ErrUnsupportedBundleVersion) {
+		t.Fatalf("expected unsupported version error, got: %v", err)
+	}
+}
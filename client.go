@@ -9,6 +9,8 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
 
 	"github.com/evris99/airbyte-sdk/types"
 )
@@ -22,12 +24,20 @@ var (
 // A client to interact with the airbyte API using HTTP
 type Client struct {
 	// The underlying HTTP Client
-	HttpClient *http.Client
-	endpoint   *url.URL
+	HttpClient   *http.Client
+	endpoint     *url.URL
+	credentials  CredentialsProvider
+	timeout      time.Duration
+	userAgent    string
+	headers      http.Header
+	requestID    func() string
+	retry        RetryPolicy
+	interceptors []Interceptor
+	limits       limits
 }
 
-// Creates and returns a new airbyte API client
-func New(apiEndpoint string) (*Client, error) {
+// Creates and returns a new airbyte API client configured with the given options
+func New(apiEndpoint string, opts ...Option) (*Client, error) {
 	_, err := url.ParseRequestURI(apiEndpoint)
 	if err != nil {
 		return nil, fmt.Errorf("could not parse URL: %w", err)
@@ -38,57 +48,202 @@ func New(apiEndpoint string) (*Client, error) {
 		return nil, fmt.Errorf("could not parse URL: %w", err)
 	}
 
-	return &Client{
+	c := &Client{
 		HttpClient: &http.Client{},
 		endpoint:   endpoint,
-	}, nil
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
 }
 
-// Makes an HTTP API request with the give data as body
+// Makes an HTTP API request with the give data as body.
+// Failed requests are retried according to the retry policy of the client
 func (c *Client) makeRequest(ctx context.Context, u *url.URL, data interface{}) (*http.Response, error) {
+	return c.send(ctx, http.MethodPost, u, data)
+}
+
+// Makes an HTTP API GET request, retried according to the retry policy of the client
+func (c *Client) makeGetRequest(ctx context.Context, u *url.URL) (*http.Response, error) {
+	return c.send(ctx, http.MethodGet, u, nil)
+}
+
+// Makes an HTTP API request with the given method and data as body through the interceptors of the client
+func (c *Client) send(ctx context.Context, method string, u *url.URL, data interface{}) (*http.Response, error) {
+	call := &Call{
+		Operation: operationName(strings.TrimPrefix(u.Path, c.endpoint.Path)),
+		Method:    method,
+		URL:       u,
+		Payload:   data,
+		Header:    make(http.Header),
+	}
+
+	invoke := c.execute
+	for i := len(c.interceptors) - 1; i >= 0; i-- {
+		interceptor, next := c.interceptors[i], invoke
+		invoke = func(ctx context.Context, call *Call) (*http.Response, error) {
+			return interceptor(ctx, call, next)
+		}
+	}
+
+	return invoke(ctx, call)
+}
+
+// Executes the call, retrying failed attempts according to the retry policy of the client
+func (c *Client) execute(ctx context.Context, call *Call) (*http.Response, error) {
 	// If the data exists encode it to json
-	var httpBodyReader io.Reader
-	if data != nil {
-		jsonData, err := json.Marshal(data)
+	var body []byte
+	if call.Payload != nil {
+		jsonData, err := json.Marshal(call.Payload)
 		if err != nil {
 			return nil, fmt.Errorf("could not encode data: %w", err)
 		}
-		httpBodyReader = bytes.NewReader(jsonData)
+		body = jsonData
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", u.String(), httpBodyReader)
-	if err != nil {
-		return nil, fmt.Errorf("could not create request: %w", err)
+	maxAttempts := 1
+	if c.retry.allows(call.URL) {
+		maxAttempts = c.retry.MaxAttempts
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	for attempt := 1; ; attempt++ {
+		res, err := c.doRequest(ctx, call, body)
+		if attempt >= maxAttempts || ctx.Err() != nil || !shouldRetry(res, err) {
+			if err != nil {
+				return nil, err
+			}
 
-	res, err := c.HttpClient.Do(req)
+			// If response code is not 2XX return error
+			if res.StatusCode >= 300 || res.StatusCode < 200 {
+				defer res.Body.Close()
+				return nil, c.getErrorResponse(res)
+			}
+
+			return res, nil
+		}
+
+		delay := c.retry.backoff(attempt, res)
+		if res != nil {
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("could not execute request: %w", ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// Makes a single attempt of an HTTP API request with the given JSON body.
+// The response is returned regardless of its status code
+func (c *Client) doRequest(ctx context.Context, call *Call, body []byte) (*http.Response, error) {
+	// Wait for the rate and concurrency limits before starting the timeout.
+	// The limits are released once the response headers arrive, not when the body is closed,
+	// so callers that keep a body open while making other calls, such as the iterators, do not block each other
+	release, err := c.limits.acquire(ctx, call.Operation)
 	if err != nil {
 		return nil, fmt.Errorf("could not execute request: %w", err)
 	}
+	defer release()
+
+	// Limit the whole request, including reading the body, to the configured timeout
+	cancel := context.CancelFunc(func() {})
+	if c.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+	}
+
+	var httpBodyReader io.Reader
+	if body != nil {
+		httpBodyReader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, call.Method, call.URL.String(), httpBodyReader)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("could not create request: %w", err)
+	}
+
+	for key, values := range c.headers {
+		req.Header[key] = append([]string(nil), values...)
+	}
+
+	for key, values := range call.Header {
+		req.Header[key] = append([]string(nil), values...)
+	}
+
+	if call.Method != http.MethodGet {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	if c.requestID != nil {
+		req.Header.Set(requestIDHeader, c.requestID())
+	}
+
+	if c.credentials != nil {
+		if err := c.credentials.SetCredentials(ctx, req); err != nil {
+			cancel()
+			return nil, fmt.Errorf("could not set credentials: %w", err)
+		}
+	}
 
-	// If response code is not 2XX return error
-	if res.StatusCode >= 300 || res.StatusCode < 200 {
-		return nil, getErrorResponse(res)
+	res, err := c.HttpClient.Do(req)
+	if err != nil {
+		cancel()
+		return nil, &transportError{err: err}
 	}
 
+	res.Body = &cancelOnClose{ReadCloser: res.Body, cancel: cancel}
 	return res, nil
 }
 
+// A response body that releases the context of its request when closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
 // Receives an HTTP response with a non 2XX status code
 // And returns the according error
-func getErrorResponse(res *http.Response) error {
-	if res.StatusCode >= 400 && res.StatusCode < 600 {
-		responseError, err := types.ResponseErrorFromJSON(res.Body)
-		if err != nil {
-			return fmt.Errorf("could not decode error response: %v", err)
-		}
+func (c *Client) getErrorResponse(res *http.Response) error {
+	apiErr := &APIError{
+		StatusCode: res.StatusCode,
+		Method:     res.Request.Method,
+		Path:       strings.TrimPrefix(res.Request.URL.Path, c.endpoint.Path),
+		RequestID:  res.Request.Header.Get(requestIDHeader),
+	}
+
+	if apiErr.RequestID == "" {
+		apiErr.RequestID = res.Header.Get(requestIDHeader)
+	}
 
-		return responseError
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxErrorBodySize))
+	if err != nil {
+		return fmt.Errorf("could not read error response: %w", err)
+	}
+
+	responseError, err := types.ResponseErrorFromJSON(bytes.NewReader(body))
+	if err != nil {
+		apiErr.Body = body
+		return apiErr
 	}
 
-	return ErrInvalidStatus
+	apiErr.Response = responseError
+	return apiErr
 }
 
 func appendToURL(u *url.URL, path string) (*url.URL, error) {
@@ -5,12 +5,17 @@ import (
 	"fmt"
 
 	airbytesdk "github.com/evris99/airbyte-sdk"
+	"github.com/evris99/airbyte-sdk/airbytetest"
 	"github.com/evris99/airbyte-sdk/types"
 	"github.com/google/uuid"
 )
 
-func ExampleCreateConnection() {
-	client, err := airbytesdk.New("http://localhost:8000/api")
+func ExampleClient_CreateConnection() {
+	// Use a fake airbyte server for the example
+	srv := airbytetest.NewServer()
+	defer srv.Close()
+
+	client, err := airbytesdk.New(srv.Endpoint())
 	if err != nil {
 		panic(err)
 	}
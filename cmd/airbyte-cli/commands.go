@@ -0,0 +1,270 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	airbytesdk "github.com/evris99/airbyte-sdk"
+	"github.com/evris99/airbyte-sdk/types"
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+func listWorkspaces(ctx context.Context, env *environment, args []string) (*result, error) {
+	fs := env.flags("workspaces list")
+	if err := env.parse(fs, args); err != nil {
+		return nil, err
+	}
+
+	workspaces, err := env.client.ListWorkspaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &result{value: workspaces, header: []string{"ID", "NAME", "SLUG"}}
+	for _, w := range workspaces {
+		res.rows = append(res.rows, []string{idString(w.WorkspaceId), w.Name, w.Slug})
+	}
+
+	return res, nil
+}
+
+func listSources(ctx context.Context, env *environment, args []string) (*result, error) {
+	fs := env.flags("sources list")
+	workspace := fs.String("workspace", "", "the ID of the workspace")
+	if err := env.parse(fs, args); err != nil {
+		return nil, err
+	}
+
+	workspaceID, err := parseID("workspace", *workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	sources, err := env.client.ListWorkspaceSources(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &result{value: sources, header: []string{"ID", "NAME", "CONNECTOR"}}
+	for _, s := range sources {
+		res.rows = append(res.rows, []string{idString(s.SourceId), s.Name, s.SourceName})
+	}
+
+	return res, nil
+}
+
+func createSource(ctx context.Context, env *environment, args []string) (*result, error) {
+	fs := env.flags("sources create")
+	file := fs.String("f", "", "the JSON or YAML file with the source to create")
+	if err := env.parse(fs, args); err != nil {
+		return nil, err
+	}
+
+	if *file == "" {
+		return nil, fmt.Errorf("%w: the file of the source is required", errUsage)
+	}
+
+	source := new(types.Source)
+	if err := readDocument(*file, source); err != nil {
+		return nil, err
+	}
+
+	created, err := env.client.CreateSource(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result{
+		value:  created,
+		header: []string{"ID", "NAME", "CONNECTOR"},
+		rows:   [][]string{{idString(created.SourceId), created.Name, created.SourceName}},
+	}, nil
+}
+
+func listDestinations(ctx context.Context, env *environment, args []string) (*result, error) {
+	fs := env.flags("destinations list")
+	workspace := fs.String("workspace", "", "the ID of the workspace")
+	if err := env.parse(fs, args); err != nil {
+		return nil, err
+	}
+
+	workspaceID, err := parseID("workspace", *workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	destinations, err := env.client.ListWorkspaceDestinations(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &result{value: destinations, header: []string{"ID", "NAME", "CONNECTOR"}}
+	for _, d := range destinations {
+		res.rows = append(res.rows, []string{idString(d.DestinationId), d.Name, d.DestinationName})
+	}
+
+	return res, nil
+}
+
+func listConnections(ctx context.Context, env *environment, args []string) (*result, error) {
+	fs := env.flags("connections list")
+	workspace := fs.String("workspace", "", "the ID of the workspace")
+	if err := env.parse(fs, args); err != nil {
+		return nil, err
+	}
+
+	workspaceID, err := parseID("workspace", *workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	connections, err := env.client.ListWorkspaceConnections(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &result{value: connections, header: []string{"ID", "NAME", "STATUS", "SOURCE", "DESTINATION"}}
+	for _, c := range connections {
+		res.rows = append(res.rows, []string{idString(c.ConnectionId), c.Name, string(c.Status), idString(c.SourceID), idString(c.DestinationId)})
+	}
+
+	return res, nil
+}
+
+func syncConnection(ctx context.Context, env *environment, args []string) (*result, error) {
+	fs := env.flags("connections sync")
+	id := fs.String("id", "", "the ID of the connection")
+	wait := fs.Bool("wait", false, "wait for the sync job to finish")
+	timeout := fs.Duration("timeout", 0, "the maximum time to wait for the job, without a limit if zero")
+	interval := fs.Duration("interval", 5*time.Second, "the interval between polls of the job")
+	if err := env.parse(fs, args); err != nil {
+		return nil, err
+	}
+
+	connectionID, err := parseID("connection", *id)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := env.client.SyncConnection(ctx, connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Job == nil {
+		return nil, errNoJob
+	}
+
+	if !*wait {
+		return jobResult(job.Job, job), nil
+	}
+
+	result, err := env.client.WaitForJob(ctx, job.Job.ID, &airbytesdk.WaitOptions{PollInterval: *interval, MaxDuration: *timeout})
+	if err != nil {
+		return nil, fmt.Errorf("job %d: %w", job.Job.ID, err)
+	}
+
+	res := jobResult(result.Job.Job, result.Job)
+	if !result.Succeeded() {
+		return res, fmt.Errorf("%w: job %d is %s", errJobFailed, job.Job.ID, result.Status)
+	}
+
+	return res, nil
+}
+
+func updateDefinitionImage(ctx context.Context, env *environment, args []string) (*result, error) {
+	fs := env.flags("definitions update-image")
+	kind := fs.String("type", "", "the type of the definition: source or destination")
+	id := fs.String("id", "", "the ID of the definition")
+	tag := fs.String("tag", "", "the new docker image tag")
+	if err := env.parse(fs, args); err != nil {
+		return nil, err
+	}
+
+	definitionID, err := parseID("definition", *id)
+	if err != nil {
+		return nil, err
+	}
+
+	if *tag == "" {
+		return nil, fmt.Errorf("%w: the image tag is required", errUsage)
+	}
+
+	header := []string{"ID", "NAME", "IMAGE"}
+	switch *kind {
+	case "source":
+		def, err := env.client.UpdateSourceDefinitionDockerImage(ctx, definitionID, *tag)
+		if err != nil {
+			return nil, err
+		}
+
+		return &result{value: def, header: header, rows: [][]string{{idString(def.SourceDefinitionId), def.Name, def.DockerRepository + ":" + def.DockerImageTag}}}, nil
+	case "destination":
+		def, err := env.client.UpdateDestinationDefinitionDockerImage(ctx, definitionID, *tag)
+		if err != nil {
+			return nil, err
+		}
+
+		return &result{value: def, header: header, rows: [][]string{{idString(def.DestinationDefinitionId), def.Name, def.DockerRepository + ":" + def.DockerImageTag}}}, nil
+	}
+
+	return nil, fmt.Errorf("%w: unknown definition type %q", errUsage, *kind)
+}
+
+func jobResult(job *types.Job, value interface{}) *result {
+	return &result{
+		value:  value,
+		header: []string{"ID", "TYPE", "STATUS"},
+		rows:   [][]string{{strconv.FormatInt(job.ID, 10), string(job.ConfigType), string(job.Status)}},
+	}
+}
+
+// Decodes the JSON or YAML document in the file into v, using the JSON field names
+func readDocument(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var document interface{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return fmt.Errorf("could not parse %s: %w", path, err)
+	}
+
+	jsonData, err := json.Marshal(document)
+	if err != nil {
+		return fmt.Errorf("could not parse %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(jsonData, v); err != nil {
+		return fmt.Errorf("could not parse %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func parseID(name, value string) (*uuid.UUID, error) {
+	if value == "" {
+		return nil, fmt.Errorf("%w: the ID of the %s is required", errUsage, name)
+	}
+
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid %s ID %q", errUsage, name, value)
+	}
+
+	return &id, nil
+}
+
+func idString(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+
+	return id.String()
+}
@@ -0,0 +1,234 @@
+// Command airbyte-cli manages an airbyte deployment from the command line.
+//
+// Usage:
+//
+//	airbyte-cli <resource> <action> [flags]
+//
+// The commands are:
+//
+//	workspaces list
+//	sources list --workspace ID
+//	sources create -f source.json
+//	destinations list --workspace ID
+//	connections list --workspace ID
+//	connections sync --id ID [--wait] [--timeout DURATION]
+//	definitions update-image --type source|destination --id ID --tag TAG
+//
+// Every command accepts the following flags, which default to the environment variables in brackets:
+//
+//	--endpoint   the URL of the airbyte API [AIRBYTE_ENDPOINT], defaults to http://localhost:8000/api
+//	--username   the username for basic authentication [AIRBYTE_USERNAME]
+//	--password   the password for basic authentication [AIRBYTE_PASSWORD]
+//	--token      the bearer token for authentication [AIRBYTE_TOKEN]
+//	-o           the output format: table, json or yaml [AIRBYTE_OUTPUT], defaults to table
+//
+// The exit code is 0 on success, 1 on unexpected errors, 2 on invalid usage, 3 if a resource was not found,
+// 4 if the server rejected the request as invalid, 5 if the server failed or could not be reached,
+// 6 if a job did not succeed and 7 if waiting for a job timed out.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+
+	airbytesdk "github.com/evris99/airbyte-sdk"
+)
+
+const defaultEndpoint = "http://localhost:8000/api"
+
+// The exit codes of the command
+const (
+	exitOK = iota
+	exitError
+	exitUsage
+	exitNotFound
+	exitInvalid
+	exitServer
+	exitJobFailed
+	exitTimeout
+)
+
+var (
+	errUsage     = errors.New("invalid usage")
+	errJobFailed = errors.New("job did not succeed")
+	errNoJob     = errors.New("the response did not include a job")
+)
+
+// The settings shared by all commands
+type globalOptions struct {
+	endpoint string
+	username string
+	password string
+	token    string
+	output   string
+}
+
+// A command is given the client and its own arguments and returns the value to print
+type command struct {
+	usage string
+	run   func(ctx context.Context, env *environment, args []string) (*result, error)
+}
+
+// What a command needs from the outside world
+type environment struct {
+	getenv func(string) string
+	stdout io.Writer
+	stderr io.Writer
+	opts   globalOptions
+	client *airbytesdk.Client
+}
+
+var commands = map[string]command{
+	"workspaces list":          {usage: "workspaces list", run: listWorkspaces},
+	"sources list":             {usage: "sources list --workspace ID", run: listSources},
+	"sources create":           {usage: "sources create -f FILE", run: createSource},
+	"destinations list":        {usage: "destinations list --workspace ID", run: listDestinations},
+	"connections list":         {usage: "connections list --workspace ID", run: listConnections},
+	"connections sync":         {usage: "connections sync --id ID [--wait] [--timeout DURATION]", run: syncConnection},
+	"definitions update-image": {usage: "definitions update-image --type source|destination --id ID --tag TAG", run: updateDefinitionImage},
+}
+
+func main() {
+	// Stop waiting for jobs on interrupt
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Getenv, os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+// Runs the command given by the arguments and returns the exit code
+func run(ctx context.Context, args []string, getenv func(string) string, stdout, stderr io.Writer) int {
+	env := &environment{getenv: getenv, stdout: stdout, stderr: stderr}
+
+	if len(args) < 2 {
+		printUsage(stderr)
+		return exitUsage
+	}
+
+	cmd, ok := commands[args[0]+" "+args[1]]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q\n", strings.Join(args[:2], " "))
+		printUsage(stderr)
+		return exitUsage
+	}
+
+	res, err := cmd.run(ctx, env, args[2:])
+	if err != nil {
+		if errors.Is(err, errUsage) {
+			fmt.Fprintf(stderr, "%v\nusage: airbyte-cli %s\n", err, cmd.usage)
+		} else {
+			fmt.Fprintf(stderr, "error: %v\n", err)
+		}
+
+		// Print what the command produced before failing, such as a failed job
+		if res != nil {
+			if err := writeResult(stdout, env.opts.output, res); err != nil {
+				fmt.Fprintf(stderr, "error: %v\n", err)
+			}
+		}
+
+		return exitCode(err)
+	}
+
+	if err := writeResult(stdout, env.opts.output, res); err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	return exitOK
+}
+
+// Adds the global flags to the flag set of a command
+func (env *environment) flags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(env.stderr)
+	fs.StringVar(&env.opts.endpoint, "endpoint", envOr(env.getenv, "AIRBYTE_ENDPOINT", defaultEndpoint), "the URL of the airbyte API")
+	fs.StringVar(&env.opts.username, "username", env.getenv("AIRBYTE_USERNAME"), "the username for basic authentication")
+	fs.StringVar(&env.opts.password, "password", env.getenv("AIRBYTE_PASSWORD"), "the password for basic authentication")
+	fs.StringVar(&env.opts.token, "token", env.getenv("AIRBYTE_TOKEN"), "the bearer token for authentication")
+	fs.StringVar(&env.opts.output, "o", envOr(env.getenv, "AIRBYTE_OUTPUT", "table"), "the output format: table, json or yaml")
+	return fs
+}
+
+// Parses the arguments of a command and creates the client
+func (env *environment) parse(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+
+	if fs.NArg() > 0 {
+		return fmt.Errorf("%w: unexpected arguments %v", errUsage, fs.Args())
+	}
+
+	switch env.opts.output {
+	case "table", "json", "yaml":
+	default:
+		return fmt.Errorf("%w: unknown output format %q", errUsage, env.opts.output)
+	}
+
+	opts := []airbytesdk.Option{airbytesdk.WithUserAgent("airbyte-cli")}
+	if env.opts.token != "" {
+		opts = append(opts, airbytesdk.WithBearerToken(env.opts.token))
+	} else if env.opts.username != "" {
+		opts = append(opts, airbytesdk.WithBasicAuth(env.opts.username, env.opts.password))
+	}
+
+	client, err := airbytesdk.New(env.opts.endpoint, opts...)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+
+	env.client = client
+	return nil
+}
+
+// Returns the exit code for the error of a command
+func exitCode(err error) int {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, errUsage):
+		return exitUsage
+	case errors.Is(err, airbytesdk.ErrWaitTimeout):
+		return exitTimeout
+	case errors.Is(err, errJobFailed):
+		return exitJobFailed
+	case airbytesdk.IsNotFound(err):
+		return exitNotFound
+	case airbytesdk.IsValidation(err):
+		return exitInvalid
+	case airbytesdk.IsServerError(err), errors.As(err, &netErr):
+		return exitServer
+	}
+
+	return exitError
+}
+
+func printUsage(w io.Writer) {
+	var usages []string
+	for _, cmd := range commands {
+		usages = append(usages, cmd.usage)
+	}
+	sort.Strings(usages)
+
+	fmt.Fprintln(w, "usage: airbyte-cli <resource> <action> [flags]")
+	fmt.Fprintln(w, "\ncommands:")
+	for _, usage := range usages {
+		fmt.Fprintf(w, "  %s\n", usage)
+	}
+}
+
+func envOr(getenv func(string) string, key, fallback string) string {
+	if value := getenv(key); value != "" {
+		return value
+	}
+
+	return fallback
+}
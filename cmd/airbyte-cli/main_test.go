@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	airbytesdk "github.com/evris99/airbyte-sdk"
+	"github.com/evris99/airbyte-sdk/airbytetest"
+	"github.com/evris99/airbyte-sdk/types"
+)
+
+// Runs the command against the server and returns its exit code and output
+func runCommand(t *testing.T, srv *airbytetest.Server, args ...string) (int, string, string) {
+	t.Helper()
+
+	env := map[string]string{"AIRBYTE_ENDPOINT": srv.Endpoint()}
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), args, func(key string) string { return env[key] }, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestWorkspacesList(t *testing.T) {
+	srv := airbytetest.NewServer()
+	defer srv.Close()
+
+	client, err := airbytesdk.New(srv.Endpoint())
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	workspace, err := client.CreateWorkspace(context.Background(), &types.Workspace{Name: "analytics"})
+	if err != nil {
+		t.Fatalf("could not create workspace: %v", err)
+	}
+
+	code, stdout, stderr := runCommand(t, srv, "workspaces", "list")
+	if code != exitOK || !strings.Contains(stdout, "analytics") || !strings.HasPrefix(stdout, "ID") {
+		t.Fatalf("unexpected table output (%d): %s %s", code, stdout, stderr)
+	}
+
+	code, stdout, _ = runCommand(t, srv, "workspaces", "list", "-o", "json")
+	var workspaces []types.Workspace
+	if err := json.Unmarshal([]byte(stdout), &workspaces); code != exitOK || err != nil || len(workspaces) != 1 {
+		t.Fatalf("unexpected json output (%d): %s", code, stdout)
+	}
+
+	code, stdout, _ = runCommand(t, srv, "workspaces", "list", "-o", "yaml")
+	if code != exitOK || !strings.Contains(stdout, "workspaceId: "+workspace.WorkspaceId.String()) {
+		t.Fatalf("unexpected yaml output (%d): %s", code, stdout)
+	}
+}
+
+func TestSourcesCreate(t *testing.T) {
+	srv := airbytetest.NewServer()
+	defer srv.Close()
+
+	client, err := airbytesdk.New(srv.Endpoint())
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	ctx := context.Background()
+	workspace, err := client.CreateWorkspace(ctx, &types.Workspace{Name: "analytics"})
+	if err != nil {
+		t.Fatalf("could not create workspace: %v", err)
+	}
+
+	definitions, err := client.ListSourceDefinitions(ctx)
+	if err != nil {
+		t.Fatalf("could not list source definitions: %v", err)
+	}
+
+	file := filepath.Join(t.TempDir(), "source.yaml")
+	document := "name: pokeapi\nworkspaceId: " + workspace.WorkspaceId.String() +
+		"\nsourceDefinitionId: " + definitions[0].SourceDefinitionId.String() +
+		"\nconnectionConfiguration:\n  pokemon_name: snorlax\n"
+	if err := os.WriteFile(file, []byte(document), 0o600); err != nil {
+		t.Fatalf("could not write source: %v", err)
+	}
+
+	code, stdout, stderr := runCommand(t, srv, "sources", "create", "-f", file)
+	if code != exitOK || !strings.Contains(stdout, "pokeapi") {
+		t.Fatalf("unexpected output (%d): %s %s", code, stdout, stderr)
+	}
+
+	sources, err := client.ListWorkspaceSources(ctx, workspace.WorkspaceId)
+	if err != nil || len(sources) != 1 || sources[0].ConnectionConfiguration["pokemon_name"] != "snorlax" {
+		t.Fatalf("source was not created: %v %+v", err, sources)
+	}
+}
+
+func TestConnectionsSyncWait(t *testing.T) {
+	srv := airbytetest.NewServer()
+	defer srv.Close()
+
+	client, err := airbytesdk.New(srv.Endpoint())
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	ctx := context.Background()
+	workspace, _ := client.CreateWorkspace(ctx, &types.Workspace{Name: "analytics"})
+	sourceDefinitions, _ := client.ListSourceDefinitions(ctx)
+	destinationDefinitions, _ := client.ListDestinationDefinitions(ctx)
+	source, _ := client.CreateSource(ctx, &types.Source{Name: "source", WorkspaceId: workspace.WorkspaceId, SourceDefinitionId: sourceDefinitions[0].SourceDefinitionId})
+	destination, _ := client.CreateDestination(ctx, &types.Destination{Name: "destination", WorkspaceId: workspace.WorkspaceId, DestinationDefinitionId: destinationDefinitions[0].DestinationDefinitionId})
+	conn, err := client.CreateConnection(ctx, &types.Connection{Name: "connection", SourceID: source.SourceId, DestinationId: destination.DestinationId, Status: types.Active})
+	if err != nil {
+		t.Fatalf("could not create connection: %v", err)
+	}
+
+	// Fail the first job while the command waits for it
+	go func() {
+		for srv.Requests("/v1/jobs/get") == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		srv.FinishJob(1, types.JobFailed, nil)
+	}()
+
+	code, stdout, stderr := runCommand(t, srv, "connections", "sync", "--id", conn.ConnectionId.String(), "--wait", "--interval", "5ms")
+	if code != exitJobFailed || !strings.Contains(stdout, "failed") || !strings.Contains(stderr, "did not succeed") {
+		t.Fatalf("unexpected output (%d): %s %s", code, stdout, stderr)
+	}
+
+	code, _, _ = runCommand(t, srv, "connections", "sync", "--id", conn.ConnectionId.String(), "--wait", "--interval", "5ms", "--timeout", "20ms")
+	if code != exitTimeout {
+		t.Fatalf("expected timeout exit code, got %d", code)
+	}
+}
+
+func TestConnectionsSyncWithoutJob(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("{}"))
+	}))
+	defer srv.Close()
+
+	env := map[string]string{"AIRBYTE_ENDPOINT": srv.URL + "/api"}
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{"connections", "sync", "--id", "6d6ad52a-53a6-4d2e-a7f2-b3e2ac6a5e8a"}, func(key string) string { return env[key] }, &stdout, &stderr)
+	if code != exitError || !strings.Contains(stderr.String(), "did not include a job") {
+		t.Fatalf("unexpected output (%d): %s %s", code, stdout.String(), stderr.String())
+	}
+}
+
+func TestDefinitionsUpdateImage(t *testing.T) {
+	srv := airbytetest.NewServer()
+	defer srv.Close()
+
+	id := srv.AddDestinationDefinition(&types.DestinationDefinition{Definition: types.Definition{Name: "Postgres", DockerRepository: "airbyte/destination-postgres", DockerImageTag: "0.3.0"}})
+
+	code, stdout, stderr := runCommand(t, srv, "definitions", "update-image", "--type", "destination", "--id", id.String(), "--tag", "0.3.1")
+	if code != exitOK || !strings.Contains(stdout, "airbyte/destination-postgres:0.3.1") {
+		t.Fatalf("unexpected output (%d): %s %s", code, stdout, stderr)
+	}
+}
+
+func TestExitCodes(t *testing.T) {
+	srv := airbytetest.NewServer()
+	defer srv.Close()
+
+	tests := []struct {
+		args []string
+		code int
+	}{
+		{[]string{"workspaces"}, exitUsage},
+		{[]string{"workspaces", "delete"}, exitUsage},
+		{[]string{"workspaces", "list", "-o", "xml"}, exitUsage},
+		{[]string{"sources", "list", "--workspace", "not-an-id"}, exitUsage},
+		{[]string{"sources", "list", "--workspace", "6d6ad52a-53a6-4d2e-a7f2-b3e2ac6a5e8a"}, exitNotFound},
+		{[]string{"definitions", "update-image", "--type", "source", "--id", "6d6ad52a-53a6-4d2e-a7f2-b3e2ac6a5e8a"}, exitUsage},
+	}
+
+	for _, test := range tests {
+		if code, _, stderr := runCommand(t, srv, test.args...); code != test.code {
+			t.Errorf("%v: expected exit code %d, got %d: %s", test.args, test.code, code, stderr)
+		}
+	}
+
+	srv.InjectFailure("/v1/workspaces/list", airbytetest.Failure{Status: 500})
+	if code, _, _ := runCommand(t, srv, "workspaces", "list"); code != exitServer {
+		t.Errorf("expected server exit code, got %d", code)
+	}
+}
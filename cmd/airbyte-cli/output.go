@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The value produced by a command along with its table form
+type result struct {
+	value  interface{}
+	header []string
+	rows   [][]string
+}
+
+// Writes the result in the given format
+func writeResult(w io.Writer, format string, res *result) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(res.value)
+	case "yaml":
+		// Encode through JSON, so the field names match the API
+		data, err := json.Marshal(res.value)
+		if err != nil {
+			return err
+		}
+
+		var document interface{}
+		if err := json.Unmarshal(data, &document); err != nil {
+			return err
+		}
+
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(document); err != nil {
+			return err
+		}
+
+		return enc.Close()
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(res.header, "\t"))
+	for _, row := range res.rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+
+	return tw.Flush()
+}
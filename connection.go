@@ -59,6 +59,15 @@ func (c *Client) ListWorkspaceConnections(ctx context.Context, workspaceID *uuid
 	return types.ConnectionsFromJSON(res.Body)
 }
 
+// IterateWorkspaceConnections returns an iterator over the connections of the workspace that decodes them one at a time.
+// Does not return deleted connections
+func (c *Client) IterateWorkspaceConnections(ctx context.Context, workspaceID *uuid.UUID) *ConnectionIterator {
+	data := make(map[string]*uuid.UUID)
+	data["workspaceId"] = workspaceID
+
+	return &ConnectionIterator{listIterator: c.newListIterator(ctx, "/v1/connections/list", data, "connections")}
+}
+
 // ListAllWorkspaceConnections lists all connections for workspace, including deleted connections.
 func (c *Client) ListAllWorkspaceConnections(ctx context.Context, workspaceID *uuid.UUID) ([]types.Connection, error) {
 	u, err := appendToURL(c.endpoint, "/v1/connections/list_all")
@@ -78,6 +87,14 @@ func (c *Client) ListAllWorkspaceConnections(ctx context.Context, workspaceID *u
 	return types.ConnectionsFromJSON(res.Body)
 }
 
+// IterateAllWorkspaceConnections returns an iterator over all connections of the workspace, including deleted connections
+func (c *Client) IterateAllWorkspaceConnections(ctx context.Context, workspaceID *uuid.UUID) *ConnectionIterator {
+	data := make(map[string]*uuid.UUID)
+	data["workspaceId"] = workspaceID
+
+	return &ConnectionIterator{listIterator: c.newListIterator(ctx, "/v1/connections/list_all", data, "connections")}
+}
+
 // GetConnection returns the connection with the given ID
 func (c *Client) GetConnection(ctx context.Context, id *uuid.UUID) (*types.Connection, error) {
 	u, err := appendToURL(c.endpoint, "/v1/connections/get")
@@ -131,3 +148,41 @@ func (c *Client) DeleteConnection(ctx context.Context, id *uuid.UUID) error {
 
 	return nil
 }
+
+// SyncConnection triggers a manual sync of the connection with the given ID and returns the created job
+func (c *Client) SyncConnection(ctx context.Context, id *uuid.UUID) (*types.JobDetails, error) {
+	u, err := appendToURL(c.endpoint, "/v1/connections/sync")
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]*uuid.UUID)
+	data["connectionId"] = id
+
+	res, err := c.makeRequest(ctx, u, data)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	return types.JobDetailsFromJSON(res.Body)
+}
+
+// ResetConnection resets the data of the connection with the given ID and returns the created job
+func (c *Client) ResetConnection(ctx context.Context, id *uuid.UUID) (*types.JobDetails, error) {
+	u, err := appendToURL(c.endpoint, "/v1/connections/reset")
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]*uuid.UUID)
+	data["connectionId"] = id
+
+	res, err := c.makeRequest(ctx, u, data)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	return types.JobDetailsFromJSON(res.Body)
+}
@@ -0,0 +1,90 @@
+package airbytesdk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/evris99/airbyte-sdk/airbytetest"
+	"github.com/evris99/airbyte-sdk/types"
+)
+
+// Creates a workspace with a source, a destination and an active connection between them
+func createTestConnection(t *testing.T, airbyte *Client) *types.Connection {
+	t.Helper()
+	ctx := context.Background()
+
+	workspace, err := airbyte.CreateWorkspace(ctx, &types.Workspace{Name: "test"})
+	if err != nil {
+		t.Fatalf("could not create workspace: %v", err)
+	}
+
+	sourceDefs, err := airbyte.ListSourceDefinitions(ctx)
+	if err != nil || len(sourceDefs) == 0 {
+		t.Fatalf("could not list source definitions: %v", err)
+	}
+
+	destDefs, err := airbyte.ListDestinationDefinitions(ctx)
+	if err != nil || len(destDefs) == 0 {
+		t.Fatalf("could not list destination definitions: %v", err)
+	}
+
+	source, err := airbyte.CreateSource(ctx, &types.Source{
+		Name:               "source",
+		WorkspaceId:        workspace.WorkspaceId,
+		SourceDefinitionId: sourceDefs[0].SourceDefinitionId,
+	})
+	if err != nil {
+		t.Fatalf("could not create source: %v", err)
+	}
+
+	dest, err := airbyte.CreateDestination(ctx, &types.Destination{
+		Name:                    "destination",
+		WorkspaceId:             workspace.WorkspaceId,
+		DestinationDefinitionId: destDefs[0].DestinationDefinitionId,
+	})
+	if err != nil {
+		t.Fatalf("could not create destination: %v", err)
+	}
+
+	conn, err := airbyte.CreateConnection(ctx, &types.Connection{
+		Name:          "connection",
+		SourceID:      source.SourceId,
+		DestinationId: dest.DestinationId,
+		Status:        types.Active,
+	})
+	if err != nil {
+		t.Fatalf("could not create connection: %v", err)
+	}
+
+	return conn
+}
+
+func TestListWorkspaceConnections(t *testing.T) {
+	srv := airbytetest.NewServer()
+	defer srv.Close()
+
+	airbyte, err := New(srv.Endpoint())
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	conn := createTestConnection(t, airbyte)
+	source, err := airbyte.GetSource(context.Background(), conn.SourceID)
+	if err != nil {
+		t.Fatalf("could not get source: %v", err)
+	}
+
+	if err := airbyte.DeleteConnection(context.Background(), conn.ConnectionId); err != nil {
+		t.Fatalf("could not delete connection: %v", err)
+	}
+
+	connections, err := airbyte.ListWorkspaceConnections(context.Background(), source.WorkspaceId)
+	if err != nil || len(connections) != 0 {
+		t.Fatalf("expected no connections, got %v and %v", connections, err)
+	}
+
+	connections, err = airbyte.ListAllWorkspaceConnections(context.Background(), source.WorkspaceId)
+	if err != nil || len(connections) != 1 {
+		t.Fatalf("expected the deleted connection, got %v and %v", connections, err)
+	}
+}
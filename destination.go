@@ -58,6 +58,14 @@ func (c *Client) ListWorkspaceDestinations(ctx context.Context, workspaceID *uui
 	return types.DestinationsFromJSON(res.Body)
 }
 
+// IterateWorkspaceDestinations returns an iterator over the destinations of the workspace that decodes them one at a time
+func (c *Client) IterateWorkspaceDestinations(ctx context.Context, workspaceID *uuid.UUID) *DestinationIterator {
+	data := make(map[string]*uuid.UUID)
+	data["workspaceId"] = workspaceID
+
+	return &DestinationIterator{listIterator: c.newListIterator(ctx, "/v1/destinations/list", data, "destinations")}
+}
+
 // GetDestination returns the destination with the given ID
 func (c *Client) GetDestination(ctx context.Context, id *uuid.UUID) (*types.Destination, error) {
 	u, err := appendToURL(c.endpoint, "/v1/destinations/get")
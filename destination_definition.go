@@ -60,6 +60,11 @@ func (c *Client) ListDestinationDefinitions(ctx context.Context) ([]types.Destin
 	return types.DestinationDefinitionsFromJSON(res.Body)
 }
 
+// IterateDestinationDefinitions returns an iterator over all destination definitions that decodes them one at a time
+func (c *Client) IterateDestinationDefinitions(ctx context.Context) *DestinationDefinitionIterator {
+	return &DestinationDefinitionIterator{listIterator: c.newListIterator(ctx, "/v1/destination_definitions/list", nil, "destinationDefinitions")}
+}
+
 // ListLatestDestinationDefinitions returns the latest destination definitions the current Airbyte deployment is configured to use
 func (c *Client) ListLatestDestinationDefinitions(ctx context.Context) ([]types.DestinationDefinition, error) {
 	u, err := appendToURL(c.endpoint, "/v1/destination_definitions/list_latest")
@@ -114,9 +119,9 @@ func (c *Client) DeleteDestinationDefinition(ctx context.Context, id *uuid.UUID)
 	return nil
 }
 
-// GetDestinationDefinitionSpecification returns the source definition specification
+// GetDestinationDefinitionSpecification returns the destination definition specification
 func (c *Client) GetDestinationDefinitionSpecification(ctx context.Context, id *uuid.UUID) (*types.DestinationDefinitionSpecification, error) {
-	u, err := appendToURL(c.endpoint, "/v1/source_definition_specifications/get")
+	u, err := appendToURL(c.endpoint, "/v1/destination_definition_specifications/get")
 	if err != nil {
 		return nil, err
 	}
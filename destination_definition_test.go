@@ -0,0 +1,39 @@
+package airbytesdk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/evris99/airbyte-sdk/airbytetest"
+	"github.com/evris99/airbyte-sdk/types"
+)
+
+func TestGetDestinationDefinitionSpecification(t *testing.T) {
+	srv := airbytetest.NewServer()
+	defer srv.Close()
+
+	airbyte, err := New(srv.Endpoint())
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	id := srv.AddDestinationDefinition(&types.DestinationDefinition{
+		Definition: types.Definition{Name: "Test", DockerRepository: "airbyte/destination-test", DockerImageTag: "0.1.0"},
+	})
+	srv.SetDestinationDefinitionSpecification(id, &types.DestinationDefinitionSpecification{
+		DefinitionSpecification: types.DefinitionSpecification{DocumentationUrl: "https://docs.airbyte.com/destination-test"},
+	})
+
+	spec, err := airbyte.GetDestinationDefinitionSpecification(context.Background(), &id)
+	if err != nil {
+		t.Fatalf("could not get destination definition specification: %v", err)
+	}
+
+	if spec.DocumentationUrl != "https://docs.airbyte.com/destination-test" {
+		t.Fatalf("unexpected documentation URL %q", spec.DocumentationUrl)
+	}
+
+	if spec.DestinationDefinitionId == nil || *spec.DestinationDefinitionId != id {
+		t.Fatalf("unexpected destination definition ID %v", spec.DestinationDefinitionId)
+	}
+}
@@ -0,0 +1,107 @@
+package airbytesdk
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/evris99/airbyte-sdk/types"
+)
+
+// The maximum size of an error response body that is read
+const maxErrorBodySize = 1 << 20
+
+// An APIError is returned when the server responds with a non 2XX status code.
+// It wraps the decoded *types.ResponseError, so it can be retrieved with errors.As
+type APIError struct {
+	// The HTTP status code of the response
+	StatusCode int
+	// The HTTP method of the request
+	Method string
+	// The path of the endpoint relative to the API URL, for example /v1/sources/get
+	Path string
+	// The ID of the request, if one was sent or returned by the server
+	RequestID string
+	// The decoded error response. It is nil if the response could not be decoded
+	Response *types.ResponseError
+	// The raw response body. It is only set if the response could not be decoded
+	Body []byte
+}
+
+// The implementation of the error interface for APIError
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("airbyte: %s %s: %d %s", e.Method, e.Path, e.StatusCode, http.StatusText(e.StatusCode))
+
+	switch {
+	case e.Response != nil && e.Response.Message != "":
+		msg += ": " + e.Response.Message
+	case len(e.Body) > 0:
+		msg += ": " + string(e.Body)
+	}
+
+	return msg
+}
+
+// Unwrap returns the decoded error response
+func (e *APIError) Unwrap() error {
+	if e.Response == nil {
+		return nil
+	}
+
+	return e.Response
+}
+
+// Is reports whether the error matches ErrServer for 5XX responses
+// or ErrInvalidStatus for responses that are neither 4XX or 5XX
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrServer:
+		return e.StatusCode >= 500 && e.StatusCode < 600
+	case ErrInvalidStatus:
+		return e.StatusCode < 400 || e.StatusCode >= 600
+	}
+
+	return false
+}
+
+// ValidationErrors returns the validation errors of the response, if any
+func (e *APIError) ValidationErrors() []types.ValidationError {
+	if e.Response == nil {
+		return nil
+	}
+
+	return e.Response.ValidationErrors
+}
+
+// IsNotFound returns true if the error is an API error for a resource that does not exist
+func IsNotFound(err error) bool {
+	return hasStatus(err, func(status int) bool { return status == http.StatusNotFound })
+}
+
+// IsConflict returns true if the error is an API error caused by a conflict with the current state of a resource
+func IsConflict(err error) bool {
+	return hasStatus(err, func(status int) bool { return status == http.StatusConflict })
+}
+
+// IsValidation returns true if the error is an API error caused by invalid input
+func IsValidation(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	return apiErr.StatusCode == http.StatusBadRequest ||
+		apiErr.StatusCode == http.StatusUnprocessableEntity ||
+		len(apiErr.ValidationErrors()) > 0
+}
+
+// IsServerError returns true if the error is an API error with a 5XX status code
+func IsServerError(err error) bool {
+	return errors.Is(err, ErrServer)
+}
+
+// Returns true if the error is an API error with a status code matching the given function
+func hasStatus(err error, match func(status int) bool) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && match(apiErr.StatusCode)
+}
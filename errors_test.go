@@ -0,0 +1,68 @@
+package airbytesdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/evris99/airbyte-sdk/types"
+	"github.com/google/uuid"
+)
+
+func TestAPIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/sources/get":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"id":"abc","message":"Could not find source"}`))
+		case "/api/v1/sources/create":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"message":"invalid","validationErrors":[{"propertyPath":"name","message":"must not be empty"}]}`))
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte(`<html>Bad Gateway</html>`))
+		}
+	}))
+	defer srv.Close()
+
+	airbyte, err := New(srv.URL+"/api", WithRequestIDGenerator(func() string { return "request-1" }))
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	id := uuid.New()
+	_, err = airbyte.GetSource(context.Background(), &id)
+	if !IsNotFound(err) || IsServerError(err) || IsValidation(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Path != "/v1/sources/get" || apiErr.Method != "POST" || apiErr.RequestID != "request-1" {
+		t.Fatalf("unexpected API error: %+v", apiErr)
+	}
+
+	var responseErr *types.ResponseError
+	if !errors.As(err, &responseErr) || responseErr.ID != "abc" {
+		t.Fatal("expected the decoded response error to be wrapped")
+	}
+
+	_, err = airbyte.CreateSource(context.Background(), &types.Source{})
+	if !IsValidation(err) || IsNotFound(err) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+
+	if errors.As(err, &apiErr); len(apiErr.ValidationErrors()) != 1 {
+		t.Fatal("expected the validation errors of the response")
+	}
+
+	_, err = airbyte.ListWorkspaces(context.Background())
+	if !IsServerError(err) || !errors.Is(err, ErrServer) {
+		t.Fatalf("expected a server error, got %v", err)
+	}
+
+	if errors.As(err, &apiErr); apiErr.Response != nil || string(apiErr.Body) != "<html>Bad Gateway</html>" {
+		t.Fatalf("expected the raw body, got %q", apiErr.Body)
+	}
+}
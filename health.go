@@ -0,0 +1,100 @@
+package airbytesdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/evris99/airbyte-sdk/types"
+)
+
+var ErrServerUnavailable = errors.New("airbyte server is not available")
+
+const defaultReadyPollInterval = time.Second
+
+// Health returns the health of the airbyte server
+func (c *Client) Health(ctx context.Context) (*types.HealthCheck, error) {
+	u, err := appendToURL(c.endpoint, "/v1/health")
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.makeGetRequest(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	return types.HealthCheckFromJSON(res.Body)
+}
+
+// GetDeploymentMetadata returns information about the airbyte deployment, including its version
+func (c *Client) GetDeploymentMetadata(ctx context.Context) (*types.DeploymentMetadata, error) {
+	u, err := appendToURL(c.endpoint, "/v1/deployment/metadata")
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.makeRequest(ctx, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	return types.DeploymentMetadataFromJSON(res.Body)
+}
+
+// ServerVersion returns the parsed version of the airbyte server.
+// Development builds do not have a valid version and return types.ErrInvalidVersion
+func (c *Client) ServerVersion(ctx context.Context) (types.Version, error) {
+	metadata, err := c.GetDeploymentMetadata(ctx)
+	if err != nil {
+		return types.Version{}, err
+	}
+
+	return types.ParseVersion(metadata.Version)
+}
+
+// WaitUntilReady polls the health of the server until it is available.
+// Failed requests are ignored until the context is done or the maximum duration is reached.
+// If opts is nil the server is polled every second without a time limit
+func (c *Client) WaitUntilReady(ctx context.Context, opts *WaitOptions) error {
+	if opts == nil {
+		opts = &WaitOptions{}
+	}
+
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = defaultReadyPollInterval
+	}
+
+	// The maximum duration also limits the health checks, so a hanging request does not exceed it
+	waitCtx, cancel := withMaxDuration(ctx, opts.MaxDuration)
+	defer cancel()
+
+	for {
+		health, err := c.Health(waitCtx)
+		if err == nil && health.Available {
+			return nil
+		}
+
+		if err == nil {
+			err = ErrServerUnavailable
+		}
+
+		wait := time.NewTimer(interval)
+		select {
+		case <-waitCtx.Done():
+			wait.Stop()
+			if waitTimedOut(ctx, waitCtx) {
+				return fmt.Errorf("%w: %v", ErrWaitTimeout, err)
+			}
+
+			return fmt.Errorf("%w: %v", ctx.Err(), err)
+		case <-wait.C:
+		}
+
+		interval = nextPollInterval(interval, opts)
+	}
+}
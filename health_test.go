@@ -0,0 +1,112 @@
+package airbytesdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/evris99/airbyte-sdk/airbytetest"
+)
+
+func TestHealth(t *testing.T) {
+	srv := airbytetest.NewServer()
+	defer srv.Close()
+
+	airbyte, err := New(srv.Endpoint())
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	health, err := airbyte.Health(context.Background())
+	if err != nil {
+		t.Fatalf("could not get health: %v", err)
+	}
+
+	if !health.Available {
+		t.Fatal("server should be available")
+	}
+}
+
+func TestServerVersion(t *testing.T) {
+	srv := airbytetest.NewServer()
+	defer srv.Close()
+	srv.SetVersion("0.41.2-alpha")
+
+	airbyte, err := New(srv.Endpoint())
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	version, err := airbyte.ServerVersion(context.Background())
+	if err != nil {
+		t.Fatalf("could not get server version: %v", err)
+	}
+
+	if version.String() != "0.41.2-alpha" || !version.AtLeast(0, 41, 0) || version.AtLeast(0, 41, 2) {
+		t.Fatalf("unexpected version: %s", version)
+	}
+}
+
+func TestWaitUntilReady(t *testing.T) {
+	srv := airbytetest.NewServer()
+	defer srv.Close()
+	srv.InjectFailure("/v1/health", airbytetest.Failure{Status: http.StatusBadGateway, Times: 2})
+
+	airbyte, err := New(srv.Endpoint())
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	err = airbyte.WaitUntilReady(context.Background(), &WaitOptions{PollInterval: time.Millisecond, MaxDuration: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("could not wait for server: %v", err)
+	}
+
+	if requests := srv.Requests("/v1/health"); requests != 3 {
+		t.Fatalf("expected 3 health checks, got %d", requests)
+	}
+}
+
+func TestWaitUntilReadyTimeout(t *testing.T) {
+	// A server that is no longer listening
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+
+	airbyte, err := New(srv.URL + "/api")
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	err = airbyte.WaitUntilReady(context.Background(), &WaitOptions{PollInterval: time.Millisecond, MaxDuration: 50 * time.Millisecond})
+	if !errors.Is(err, ErrWaitTimeout) {
+		t.Fatalf("expected timeout, got: %v", err)
+	}
+}
+
+func TestWaitUntilReadyHangingCheck(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	airbyte, err := New(srv.URL + "/api")
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	start := time.Now()
+	err = airbyte.WaitUntilReady(context.Background(), &WaitOptions{PollInterval: time.Millisecond, MaxDuration: 50 * time.Millisecond})
+	if !errors.Is(err, ErrWaitTimeout) {
+		t.Fatalf("expected timeout, got: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected the wait to stop after the maximum duration, took %v", elapsed)
+	}
+}
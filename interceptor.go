@@ -0,0 +1,47 @@
+package airbytesdk
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// A Call is a single API call passing through the interceptors of a client
+type Call struct {
+	// The logical name of the operation, such as "sources.create"
+	Operation string
+	// The HTTP method of the request
+	Method string
+	// The URL of the request
+	URL *url.URL
+	// The request payload before it is encoded to JSON. Nil if the request has no body
+	Payload interface{}
+	// Headers added to every attempt of the request
+	Header http.Header
+}
+
+// An Invoker executes a call and returns the response.
+// Responses with a non 2XX status code are returned as an *APIError
+type Invoker func(ctx context.Context, call *Call) (*http.Response, error)
+
+// An Interceptor wraps every call of a client. It can modify the call before passing it to next,
+// inspect the response and the error that next returns, or skip next and return its own response.
+// A returned response must have a body, which the caller reads and closes.
+// Retries happen inside next, so an interceptor sees each call once
+type Interceptor func(ctx context.Context, call *Call, next Invoker) (*http.Response, error)
+
+// WithInterceptors adds interceptors to the client.
+// The first interceptor is the outermost, so it sees the call first and the response last
+func WithInterceptors(interceptors ...Interceptor) Option {
+	return func(c *Client) {
+		c.interceptors = append(c.interceptors, interceptors...)
+	}
+}
+
+// Returns the operation name of an API path, for example "sources.create" for /v1/sources/create
+func operationName(path string) string {
+	path = strings.TrimPrefix(path, "/")
+	path = strings.TrimPrefix(path, "v1/")
+	return strings.ReplaceAll(path, "/", ".")
+}
@@ -0,0 +1,165 @@
+package airbytesdk
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/evris99/airbyte-sdk/airbytetest"
+	"github.com/evris99/airbyte-sdk/types"
+)
+
+func TestInterceptorSeesCall(t *testing.T) {
+	srv := airbytetest.NewServer()
+	defer srv.Close()
+
+	var calls []*Call
+	var statuses []int
+	record := func(ctx context.Context, call *Call, next Invoker) (*http.Response, error) {
+		calls = append(calls, call)
+		res, err := next(ctx, call)
+		if err == nil {
+			statuses = append(statuses, res.StatusCode)
+		}
+		return res, err
+	}
+
+	airbyte, err := New(srv.Endpoint(), WithInterceptors(record))
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	workspace := &types.Workspace{Name: "intercepted"}
+	if _, err := airbyte.CreateWorkspace(context.Background(), workspace); err != nil {
+		t.Fatalf("could not create workspace: %v", err)
+	}
+
+	if _, err := airbyte.Health(context.Background()); err != nil {
+		t.Fatalf("could not check health: %v", err)
+	}
+
+	if len(calls) != 2 {
+		t.Fatalf("expected 2 calls, got %d", len(calls))
+	}
+
+	if calls[0].Operation != "workspaces.create" || calls[0].Method != http.MethodPost {
+		t.Errorf("unexpected call %s %s", calls[0].Method, calls[0].Operation)
+	}
+
+	if calls[0].Payload != workspace {
+		t.Errorf("expected the workspace as payload, got %v", calls[0].Payload)
+	}
+
+	if calls[1].Operation != "health" || calls[1].Method != http.MethodGet || calls[1].Payload != nil {
+		t.Errorf("unexpected call %s %s with payload %v", calls[1].Method, calls[1].Operation, calls[1].Payload)
+	}
+
+	if len(statuses) != 2 || statuses[0] != http.StatusOK {
+		t.Errorf("unexpected statuses %v", statuses)
+	}
+}
+
+func TestInterceptorSeesError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"not found"}`))
+	}))
+	defer srv.Close()
+
+	var seen error
+	airbyte, err := New(srv.URL+"/api", WithInterceptors(func(ctx context.Context, call *Call, next Invoker) (*http.Response, error) {
+		res, err := next(ctx, call)
+		seen = err
+		return res, err
+	}))
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	if _, err := airbyte.ListWorkspaces(context.Background()); !IsNotFound(err) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+
+	if !IsNotFound(seen) {
+		t.Fatalf("expected the interceptor to see not found, got %v", seen)
+	}
+}
+
+func TestInterceptorShortCircuit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the server should not be called")
+	}))
+	defer srv.Close()
+
+	cached := func(ctx context.Context, call *Call, next Invoker) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     make(http.Header),
+			Body:       io.NopCloser(strings.NewReader(`{"workspaces":[{"name":"cached"}]}`)),
+		}, nil
+	}
+
+	airbyte, err := New(srv.URL+"/api", WithInterceptors(cached))
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	workspaces, err := airbyte.ListWorkspaces(context.Background())
+	if err != nil {
+		t.Fatalf("could not list workspaces: %v", err)
+	}
+
+	if len(workspaces) != 1 || workspaces[0].Name != "cached" {
+		t.Fatalf("unexpected workspaces %v", workspaces)
+	}
+}
+
+func TestInterceptorOrder(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Values("X-Trace"); strings.Join(got, ",") != "outer,inner" {
+			t.Errorf("unexpected trace header %v", got)
+		}
+
+		w.Write([]byte(`{"workspaces":[]}`))
+	}))
+	defer srv.Close()
+
+	var order []string
+	tag := func(name string) Interceptor {
+		return func(ctx context.Context, call *Call, next Invoker) (*http.Response, error) {
+			order = append(order, name)
+			call.Header.Add("X-Trace", name)
+			return next(ctx, call)
+		}
+	}
+
+	airbyte, err := New(srv.URL+"/api", WithInterceptors(tag("outer")), WithInterceptors(tag("inner")))
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	if _, err := airbyte.ListWorkspaces(context.Background()); err != nil {
+		t.Fatalf("could not list workspaces: %v", err)
+	}
+
+	if strings.Join(order, ",") != "outer,inner" {
+		t.Fatalf("unexpected order %v", order)
+	}
+}
+
+func TestOperationName(t *testing.T) {
+	tests := map[string]string{
+		"/v1/sources/create":              "sources.create",
+		"/v1/web_backend/connections/get": "web_backend.connections.get",
+		"/v1/health":                      "health",
+	}
+
+	for path, want := range tests {
+		if got := operationName(path); got != want {
+			t.Errorf("operationName(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
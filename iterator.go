@@ -0,0 +1,301 @@
+package airbytesdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/evris99/airbyte-sdk/types"
+)
+
+// Requests the page of a list that starts at the given offset
+type pageFunc func(ctx context.Context, offset int) (*http.Response, error)
+
+// A listIterator decodes the elements of a JSON array field of list responses one at a time.
+// If the list is paginated, the next page is requested when the elements of a full page have been read
+type listIterator struct {
+	ctx   context.Context
+	fetch pageFunc
+	// The name of the response field holding the array
+	key string
+	// The number of elements per page. Zero means the list is not paginated
+	pageSize int
+	offset   int
+	// The number of elements read from the current page
+	read    int
+	body    io.ReadCloser
+	dec     *json.Decoder
+	inArray bool
+	done    bool
+	err     error
+}
+
+// Decodes the next element of the list into v.
+// Returns false when there are no more elements or an error occurred
+func (it *listIterator) next(v interface{}) bool {
+	for !it.done {
+		if it.dec == nil {
+			if err := it.open(); err != nil {
+				it.fail(err)
+				return false
+			}
+		}
+
+		if it.inArray && it.dec.More() {
+			if err := it.dec.Decode(v); err != nil {
+				it.fail(fmt.Errorf("could not decode %s: %w", it.key, err))
+				return false
+			}
+
+			it.read++
+			it.offset++
+			return true
+		}
+
+		// The current page is exhausted
+		full := it.pageSize > 0 && it.read >= it.pageSize
+		it.closeBody()
+		it.done = !full
+	}
+
+	return false
+}
+
+// Requests the page at the current offset and moves the decoder to the start of its array
+func (it *listIterator) open() error {
+	res, err := it.fetch(it.ctx, it.offset)
+	if err != nil {
+		return err
+	}
+
+	it.body = res.Body
+	it.dec = json.NewDecoder(res.Body)
+	it.read = 0
+	it.inArray, err = seekArray(it.dec, it.key)
+	if err != nil {
+		return fmt.Errorf("could not decode %s: %w", it.key, err)
+	}
+
+	return nil
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *listIterator) Err() error {
+	return it.err
+}
+
+// Close stops the iteration and releases the response being read.
+// It does not need to be called if Next returned false
+func (it *listIterator) Close() error {
+	it.done = true
+	return it.closeBody()
+}
+
+func (it *listIterator) fail(err error) {
+	it.err = err
+	it.done = true
+	it.closeBody()
+}
+
+func (it *listIterator) closeBody() error {
+	if it.body == nil {
+		return nil
+	}
+
+	err := it.body.Close()
+	it.body = nil
+	it.dec = nil
+	it.inArray = false
+	return err
+}
+
+// Reads the tokens of a JSON object until the start of the array in the field with the given name.
+// Other fields are skipped. Returns false if the field does not exist or is null
+func seekArray(dec *json.Decoder, key string) (bool, error) {
+	if err := expectDelim(dec, '{'); err != nil {
+		return false, err
+	}
+
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return false, err
+		}
+
+		if name, _ := token.(string); name != key {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return false, err
+			}
+
+			continue
+		}
+
+		token, err = dec.Token()
+		if err != nil {
+			return false, err
+		}
+
+		if token == nil {
+			return false, nil
+		}
+
+		if delim, ok := token.(json.Delim); !ok || delim != '[' {
+			return false, fmt.Errorf("field %q is not an array", key)
+		}
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func expectDelim(dec *json.Decoder, expected json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if delim, ok := token.(json.Delim); !ok || delim != expected {
+		return fmt.Errorf("expected %q but got %v", expected, token)
+	}
+
+	return nil
+}
+
+// Returns an iterator over the array with the given name of the response of an endpoint that is not paginated
+func (c *Client) newListIterator(ctx context.Context, path string, data interface{}, key string) *listIterator {
+	return &listIterator{
+		ctx: ctx,
+		key: key,
+		fetch: func(ctx context.Context, offset int) (*http.Response, error) {
+			u, err := appendToURL(c.endpoint, path)
+			if err != nil {
+				return nil, err
+			}
+
+			return c.makeRequest(ctx, u, data)
+		},
+	}
+}
+
+// An iterator over workspaces
+type WorkspaceIterator struct {
+	*listIterator
+	current types.Workspace
+}
+
+// Next advances to the next workspace and returns false when there are no more workspaces or an error occurred
+func (it *WorkspaceIterator) Next() bool {
+	it.current = types.Workspace{}
+	return it.next(&it.current)
+}
+
+// Workspace returns the current workspace
+func (it *WorkspaceIterator) Workspace() *types.Workspace {
+	return &it.current
+}
+
+// An iterator over sources
+type SourceIterator struct {
+	*listIterator
+	current types.Source
+}
+
+// Next advances to the next source and returns false when there are no more sources or an error occurred
+func (it *SourceIterator) Next() bool {
+	it.current = types.Source{}
+	return it.next(&it.current)
+}
+
+// Source returns the current source
+func (it *SourceIterator) Source() *types.Source {
+	return &it.current
+}
+
+// An iterator over destinations
+type DestinationIterator struct {
+	*listIterator
+	current types.Destination
+}
+
+// Next advances to the next destination and returns false when there are no more destinations or an error occurred
+func (it *DestinationIterator) Next() bool {
+	it.current = types.Destination{}
+	return it.next(&it.current)
+}
+
+// Destination returns the current destination
+func (it *DestinationIterator) Destination() *types.Destination {
+	return &it.current
+}
+
+// An iterator over connections
+type ConnectionIterator struct {
+	*listIterator
+	current types.Connection
+}
+
+// Next advances to the next connection and returns false when there are no more connections or an error occurred
+func (it *ConnectionIterator) Next() bool {
+	it.current = types.Connection{}
+	return it.next(&it.current)
+}
+
+// Connection returns the current connection
+func (it *ConnectionIterator) Connection() *types.Connection {
+	return &it.current
+}
+
+// An iterator over source definitions
+type SourceDefinitionIterator struct {
+	*listIterator
+	current types.SourceDefinition
+}
+
+// Next advances to the next source definition and returns false when there are no more definitions or an error occurred
+func (it *SourceDefinitionIterator) Next() bool {
+	it.current = types.SourceDefinition{}
+	return it.next(&it.current)
+}
+
+// SourceDefinition returns the current source definition
+func (it *SourceDefinitionIterator) SourceDefinition() *types.SourceDefinition {
+	return &it.current
+}
+
+// An iterator over destination definitions
+type DestinationDefinitionIterator struct {
+	*listIterator
+	current types.DestinationDefinition
+}
+
+// Next advances to the next destination definition and returns false when there are no more definitions or an error occurred
+func (it *DestinationDefinitionIterator) Next() bool {
+	it.current = types.DestinationDefinition{}
+	return it.next(&it.current)
+}
+
+// DestinationDefinition returns the current destination definition
+func (it *DestinationDefinitionIterator) DestinationDefinition() *types.DestinationDefinition {
+	return &it.current
+}
+
+// An iterator over jobs
+type JobIterator struct {
+	*listIterator
+	current types.JobWithAttempts
+}
+
+// Next advances to the next job and returns false when there are no more jobs or an error occurred
+func (it *JobIterator) Next() bool {
+	it.current = types.JobWithAttempts{}
+	return it.next(&it.current)
+}
+
+// Job returns the current job with its attempts
+func (it *JobIterator) Job() *types.JobWithAttempts {
+	return &it.current
+}
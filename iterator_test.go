@@ -0,0 +1,147 @@
+package airbytesdk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/evris99/airbyte-sdk/airbytetest"
+	"github.com/evris99/airbyte-sdk/types"
+)
+
+func TestIterateJobs(t *testing.T) {
+	srv := airbytetest.NewServer()
+	defer srv.Close()
+
+	airbyte, err := New(srv.Endpoint())
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	ctx := context.Background()
+	conn := createTestConnection(t, airbyte)
+	for i := 0; i < 5; i++ {
+		job, err := airbyte.SyncConnection(ctx, conn.ConnectionId)
+		if err != nil {
+			t.Fatalf("could not sync connection: %v", err)
+		}
+
+		if err := srv.FinishJob(job.Job.ID, types.JobSucceeded, nil); err != nil {
+			t.Fatalf("could not finish job: %v", err)
+		}
+	}
+
+	it := airbyte.IterateJobs(ctx, []types.ConfigTypeEnum{types.Sync}, conn.ConnectionId.String(), 2)
+	var ids []int64
+	for it.Next() {
+		ids = append(ids, it.Job().Job.ID)
+	}
+
+	if err := it.Err(); err != nil {
+		t.Fatalf("could not iterate jobs: %v", err)
+	}
+
+	if len(ids) != 5 || ids[0] != 5 || ids[4] != 1 {
+		t.Fatalf("unexpected jobs: %v", ids)
+	}
+
+	if requests := srv.Requests("/v1/jobs/list"); requests != 3 {
+		t.Fatalf("expected 3 pages, got %d", requests)
+	}
+}
+
+func TestIterateWorkspaceConnections(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"other":{"connections":[1]},"connections":[{"name":"first"},{"name":"second"}],"more":true}`))
+	}))
+	defer srv.Close()
+
+	airbyte, err := New(srv.URL + "/api")
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	it := airbyte.IterateWorkspaceConnections(context.Background(), nil)
+	var names []string
+	for it.Next() {
+		names = append(names, it.Connection().Name)
+	}
+
+	if err := it.Err(); err != nil {
+		t.Fatalf("could not iterate connections: %v", err)
+	}
+
+	if len(names) != 2 || names[0] != "first" || names[1] != "second" {
+		t.Fatalf("unexpected connections: %v", names)
+	}
+}
+
+func TestIteratorErrors(t *testing.T) {
+	tests := map[string]string{
+		"null":      `{"sources":null}`,
+		"missing":   `{}`,
+		"malformed": `{"sources":[{"name":"ok"},{"name":1}]}`,
+		"not array": `{"sources":{}}`,
+	}
+
+	for name, response := range tests {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(response))
+			}))
+			defer srv.Close()
+
+			airbyte, err := New(srv.URL + "/api")
+			if err != nil {
+				t.Fatalf("could not create instance: %v", err)
+			}
+
+			it := airbyte.IterateWorkspaceSources(context.Background(), nil)
+			count := 0
+			for it.Next() {
+				count++
+			}
+
+			switch name {
+			case "null", "missing":
+				if count != 0 || it.Err() != nil {
+					t.Fatalf("expected an empty list, got %d sources and error %v", count, it.Err())
+				}
+			case "malformed":
+				if count != 1 || it.Err() == nil {
+					t.Fatalf("expected an error after the first source, got %d sources and error %v", count, it.Err())
+				}
+			default:
+				if it.Err() == nil {
+					t.Fatal("expected an error")
+				}
+			}
+		})
+	}
+}
+
+func TestIteratorClose(t *testing.T) {
+	srv := airbytetest.NewServer()
+	defer srv.Close()
+
+	airbyte, err := New(srv.Endpoint())
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	srv.AddSourceDefinition(&types.SourceDefinition{Definition: types.Definition{Name: "Other"}})
+
+	it := airbyte.IterateSourceDefinitions(context.Background())
+	if !it.Next() {
+		t.Fatalf("expected a source definition: %v", it.Err())
+	}
+
+	if err := it.Close(); err != nil {
+		t.Fatalf("could not close iterator: %v", err)
+	}
+
+	if it.Next() {
+		t.Fatal("closed iterator should not advance")
+	}
+}
@@ -0,0 +1,90 @@
+package airbytesdk
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/evris99/airbyte-sdk/types"
+)
+
+// ListJobs returns the jobs with the given config types for the given config ID.
+// For sync and reset jobs the config ID is the ID of the connection
+func (c *Client) ListJobs(ctx context.Context, configTypes []types.ConfigTypeEnum, configID string) ([]types.JobWithAttempts, error) {
+	u, err := appendToURL(c.endpoint, "/v1/jobs/list")
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]interface{})
+	data["configTypes"] = configTypes
+	data["configId"] = configID
+
+	res, err := c.makeRequest(ctx, u, data)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	return types.JobsFromJSON(res.Body)
+}
+
+// IterateJobs returns an iterator over the jobs with the given config types for the given config ID.
+// The jobs are requested in pages of the given size. A size of zero requests all the jobs at once
+func (c *Client) IterateJobs(ctx context.Context, configTypes []types.ConfigTypeEnum, configID string, pageSize int) *JobIterator {
+	it := &listIterator{ctx: ctx, key: "jobs", pageSize: pageSize}
+	it.fetch = func(ctx context.Context, offset int) (*http.Response, error) {
+		u, err := appendToURL(c.endpoint, "/v1/jobs/list")
+		if err != nil {
+			return nil, err
+		}
+
+		data := make(map[string]interface{})
+		data["configTypes"] = configTypes
+		data["configId"] = configID
+		if pageSize > 0 {
+			data["pagination"] = map[string]int{"pageSize": pageSize, "rowOffset": offset}
+		}
+
+		return c.makeRequest(ctx, u, data)
+	}
+
+	return &JobIterator{listIterator: it}
+}
+
+// GetJob returns the job with the given ID along with its attempts
+func (c *Client) GetJob(ctx context.Context, id int64) (*types.JobDetails, error) {
+	u, err := appendToURL(c.endpoint, "/v1/jobs/get")
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]int64)
+	data["id"] = id
+
+	res, err := c.makeRequest(ctx, u, data)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	return types.JobDetailsFromJSON(res.Body)
+}
+
+// CancelJob cancels the job with the given ID and returns it
+func (c *Client) CancelJob(ctx context.Context, id int64) (*types.JobDetails, error) {
+	u, err := appendToURL(c.endpoint, "/v1/jobs/cancel")
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]int64)
+	data["id"] = id
+
+	res, err := c.makeRequest(ctx, u, data)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	return types.JobDetailsFromJSON(res.Body)
+}
@@ -0,0 +1,92 @@
+package airbytesdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/evris99/airbyte-sdk/types"
+	"github.com/google/uuid"
+)
+
+func TestSyncConnection(t *testing.T) {
+	connID := uuid.New()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/connections/sync" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("could not decode request: %v", err)
+		}
+
+		if body["connectionId"] != connID.String() {
+			t.Errorf("unexpected connection ID %s", body["connectionId"])
+		}
+
+		w.Write([]byte(`{"job":{"id":12,"configType":"sync","configId":"` + connID.String() + `","status":"running"},"attempts":[{"attempt":{"id":0,"status":"running"},"logs":{"logLines":[]}}]}`))
+	}))
+	defer srv.Close()
+
+	airbyte, err := New(srv.URL + "/api")
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	job, err := airbyte.SyncConnection(context.Background(), &connID)
+	if err != nil {
+		t.Fatalf("could not sync connection: %v", err)
+	}
+
+	if job.Job.ID != 12 || job.Job.Status != types.JobRunning || job.Job.ConfigType != types.Sync {
+		t.Fatalf("unexpected job: %+v", job.Job)
+	}
+
+	if len(job.Attempts) != 1 || job.Attempts[0].Attempt.Status != types.AttemptRunning {
+		t.Fatalf("unexpected attempts: %+v", job.Attempts)
+	}
+}
+
+func TestListJobs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/jobs/list" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+
+		var body struct {
+			ConfigTypes []types.ConfigTypeEnum `json:"configTypes"`
+			ConfigId    string                 `json:"configId"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("could not decode request: %v", err)
+		}
+
+		if len(body.ConfigTypes) != 2 || body.ConfigTypes[1] != types.ResetConnection || body.ConfigId != "conn" {
+			t.Errorf("unexpected request: %+v", body)
+		}
+
+		w.Write([]byte(`{"jobs":[{"job":{"id":1,"status":"failed"},"attempts":[{"id":0,"status":"failed","failureSummary":{"failures":[{"failureOrigin":"source","externalMessage":"boom"}]}}]}]}`))
+	}))
+	defer srv.Close()
+
+	airbyte, err := New(srv.URL + "/api")
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	jobs, err := airbyte.ListJobs(context.Background(), []types.ConfigTypeEnum{types.Sync, types.ResetConnection}, "conn")
+	if err != nil {
+		t.Fatalf("could not list jobs: %v", err)
+	}
+
+	if len(jobs) != 1 || jobs[0].Job.Status != types.JobFailed {
+		t.Fatalf("unexpected jobs: %+v", jobs)
+	}
+
+	if jobs[0].Attempts[0].FailureSummary.Failures[0].ExternalMessage != "boom" {
+		t.Fatal("failure summary was not decoded")
+	}
+}
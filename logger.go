@@ -0,0 +1,292 @@
+package airbytesdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evris99/airbyte-sdk/types"
+	"github.com/google/uuid"
+)
+
+// A Logger writes structured log records. The arguments are alternating keys and values.
+// It is implemented by *slog.Logger
+type Logger interface {
+	DebugContext(ctx context.Context, msg string, args ...interface{})
+	InfoContext(ctx context.Context, msg string, args ...interface{})
+	WarnContext(ctx context.Context, msg string, args ...interface{})
+	ErrorContext(ctx context.Context, msg string, args ...interface{})
+}
+
+// The time limit of fetching the connector specifications used to redact a request body
+const specFetchTimeout = 10 * time.Second
+
+// The parts of field names that mark a field as secret, regardless of the connector specification
+var secretKeyNames = []string{"password", "passwd", "token", "secret", "credential", "api_key", "apikey", "private_key", "access_key"}
+
+// WithLogger logs every API call with its operation, duration, status and the ID of the airbyte error.
+// Request bodies are logged at debug level with their secrets redacted when the record is formatted.
+// Handlers may format records after the call returned, so the specifications are fetched
+// with the values of the request context but without its cancellation, within a time limit of their own.
+// Secrets are the configuration fields marked with airbyte_secret in the connector specification,
+// which is fetched once per definition, and the fields with names such as password, token or credentials
+func WithLogger(logger Logger) Option {
+	return func(c *Client) {
+		l := &callLogger{client: c, logger: logger, specs: make(map[uuid.UUID]map[string]interface{}), definitions: make(map[uuid.UUID]uuid.UUID)}
+		c.interceptors = append(c.interceptors, l.intercept)
+	}
+}
+
+// Logs calls and caches the connector specifications used for redaction
+type callLogger struct {
+	client *Client
+	logger Logger
+
+	mu sync.Mutex
+	// The connection specifications by definition ID
+	specs map[uuid.UUID]map[string]interface{}
+	// The definition IDs by source or destination ID
+	definitions map[uuid.UUID]uuid.UUID
+}
+
+func (l *callLogger) intercept(ctx context.Context, call *Call, next Invoker) (*http.Response, error) {
+	if call.Payload != nil {
+		l.logger.DebugContext(ctx, "airbyte request", "operation", call.Operation, "body", &redactedBody{ctx: ctx, logger: l, payload: call.Payload})
+	}
+
+	start := time.Now()
+	res, err := next(ctx, call)
+	duration := time.Since(start)
+
+	if err == nil {
+		l.logger.InfoContext(ctx, "airbyte call", "operation", call.Operation, "duration", duration, "status", res.StatusCode)
+		return res, err
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		l.logger.ErrorContext(ctx, "airbyte call failed", "operation", call.Operation, "duration", duration, "error", err)
+		return res, err
+	}
+
+	args := []interface{}{"operation", call.Operation, "duration", duration, "status", apiErr.StatusCode}
+	if apiErr.Response != nil && apiErr.Response.ID != "" {
+		args = append(args, "error_id", apiErr.Response.ID)
+	}
+	args = append(args, "error", err)
+
+	if apiErr.StatusCode >= 500 {
+		l.logger.ErrorContext(ctx, "airbyte call failed", args...)
+	} else {
+		l.logger.WarnContext(ctx, "airbyte call failed", args...)
+	}
+
+	return res, err
+}
+
+// A request body that is redacted when it is formatted.
+// Loggers that discard debug records never format it, so they do not pay for fetching the specifications
+type redactedBody struct {
+	ctx     context.Context
+	logger  *callLogger
+	payload interface{}
+
+	once     sync.Once
+	redacted string
+}
+
+// String returns the JSON encoded body with its secrets redacted
+func (b *redactedBody) String() string {
+	b.once.Do(func() {
+		// The request context may be canceled by the time an asynchronous handler formats the record
+		ctx, cancel := context.WithTimeout(detachedContext{b.ctx}, specFetchTimeout)
+		defer cancel()
+
+		b.redacted = b.logger.redactPayload(ctx, b.payload)
+	})
+
+	return b.redacted
+}
+
+// MarshalText implements encoding.TextMarshaler, which text handlers use to format values
+func (b *redactedBody) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// MarshalJSON implements json.Marshaler, so JSON handlers embed the body as an object
+func (b *redactedBody) MarshalJSON() ([]byte, error) {
+	if body := b.String(); body != "" {
+		return []byte(body), nil
+	}
+
+	return []byte("null"), nil
+}
+
+// A context with the values of its parent that is never canceled
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
+
+// Returns the JSON encoded payload with its secrets redacted
+func (l *callLogger) redactPayload(ctx context.Context, payload interface{}) string {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return ""
+	}
+
+	var body interface{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return ""
+	}
+
+	// Redact the configuration of sources and destinations using their specification.
+	// Without the specification the secrets are unknown, so the whole configuration is redacted
+	if object, ok := body.(map[string]interface{}); ok {
+		if config, ok := object["connectionConfiguration"].(map[string]interface{}); ok {
+			if spec := l.payloadSpec(ctx, payload); spec != nil {
+				object["connectionConfiguration"], _ = redactConfiguration(config, spec)
+			} else {
+				object["connectionConfiguration"] = RedactedSecret
+			}
+		}
+	}
+
+	data, err = json.Marshal(redactSecretKeys(body))
+	if err != nil {
+		return ""
+	}
+
+	return string(data)
+}
+
+// Returns the connection specification of the source or destination in the payload, or nil if it cannot be found
+func (l *callLogger) payloadSpec(ctx context.Context, payload interface{}) map[string]interface{} {
+	switch p := payload.(type) {
+	case *types.Source:
+		return l.spec(ctx, p.SourceDefinitionId, p.SourceId, "/v1/sources/get", "sourceId", "/v1/source_definition_specifications/get", "sourceDefinitionId")
+	case *types.Destination:
+		return l.spec(ctx, p.DestinationDefinitionId, p.DestinationId, "/v1/destinations/get", "destinationId", "/v1/destination_definition_specifications/get", "destinationDefinitionId")
+	}
+
+	return nil
+}
+
+// Returns the connection specification of the definition. If the definition ID is nil it is looked up by the ID of the resource.
+// The requests bypass the interceptors, so they are not logged
+func (l *callLogger) spec(ctx context.Context, definitionID, id *uuid.UUID, getPath, idKey, specPath, definitionKey string) map[string]interface{} {
+	if definitionID == nil && id != nil {
+		l.mu.Lock()
+		cached, ok := l.definitions[*id]
+		l.mu.Unlock()
+
+		if !ok {
+			resource := make(map[string]interface{})
+			if err := l.client.fetch(ctx, getPath, map[string]*uuid.UUID{idKey: id}, &resource); err != nil {
+				return nil
+			}
+
+			parsed, err := uuid.Parse(stringValue(resource[definitionKey]))
+			if err != nil {
+				return nil
+			}
+
+			cached = parsed
+			l.mu.Lock()
+			l.definitions[*id] = cached
+			l.mu.Unlock()
+		}
+
+		definitionID = &cached
+	}
+
+	if definitionID == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	spec, ok := l.specs[*definitionID]
+	l.mu.Unlock()
+	if ok {
+		return spec
+	}
+
+	specification := new(types.DefinitionSpecification)
+	if err := l.client.fetch(ctx, specPath, map[string]*uuid.UUID{definitionKey: definitionID}, specification); err != nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	l.specs[*definitionID] = specification.ConnectionSpecification
+	l.mu.Unlock()
+
+	return specification.ConnectionSpecification
+}
+
+// Makes a request without the interceptors of the client and decodes the JSON response into v
+func (c *Client) fetch(ctx context.Context, path string, data interface{}, v interface{}) error {
+	u, err := appendToURL(c.endpoint, path)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.execute(ctx, &Call{Operation: operationName(path), Method: http.MethodPost, URL: u, Payload: data, Header: make(http.Header)})
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+// Replaces the values of the fields with secret names in the decoded JSON value
+func redactSecretKeys(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if field != nil && isSecretKey(key) {
+				v[key] = RedactedSecret
+				continue
+			}
+
+			v[key] = redactSecretKeys(field)
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = redactSecretKeys(v[i])
+		}
+	}
+
+	return value
+}
+
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, name := range secretKeyNames {
+		if strings.Contains(key, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func stringValue(v interface{}) string {
+	s, _ := v.(string)
+	return s
+}
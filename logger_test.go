@@ -0,0 +1,287 @@
+package airbytesdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/evris99/airbyte-sdk/airbytetest"
+	"github.com/evris99/airbyte-sdk/types"
+	"github.com/google/uuid"
+)
+
+type logRecord struct {
+	level string
+	msg   string
+	attrs map[string]interface{}
+}
+
+// Records the log records in memory
+type testLogger struct {
+	mu      sync.Mutex
+	records []logRecord
+}
+
+func (l *testLogger) log(level, msg string, args []interface{}) {
+	attrs := make(map[string]interface{})
+	for i := 0; i+1 < len(args); i += 2 {
+		attrs[fmt.Sprint(args[i])] = args[i+1]
+	}
+
+	l.mu.Lock()
+	l.records = append(l.records, logRecord{level: level, msg: msg, attrs: attrs})
+	l.mu.Unlock()
+}
+
+func (l *testLogger) DebugContext(ctx context.Context, msg string, args ...interface{}) {
+	l.log("debug", msg, args)
+}
+
+func (l *testLogger) InfoContext(ctx context.Context, msg string, args ...interface{}) {
+	l.log("info", msg, args)
+}
+
+func (l *testLogger) WarnContext(ctx context.Context, msg string, args ...interface{}) {
+	l.log("warn", msg, args)
+}
+
+func (l *testLogger) ErrorContext(ctx context.Context, msg string, args ...interface{}) {
+	l.log("error", msg, args)
+}
+
+func TestLoggerRedactsSecrets(t *testing.T) {
+	srv := airbytetest.NewServer()
+	defer srv.Close()
+
+	unlogged, err := New(srv.Endpoint())
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	conn := createTestConnection(t, unlogged)
+	source, err := unlogged.GetSource(context.Background(), conn.SourceID)
+	if err != nil {
+		t.Fatalf("could not get source: %v", err)
+	}
+
+	srv.SetSourceDefinitionSpecification(*source.SourceDefinitionId, &types.SourceDefinitionSpecification{
+		DefinitionSpecification: types.DefinitionSpecification{ConnectionSpecification: map[string]interface{}{
+			"properties": map[string]interface{}{
+				"pin": map[string]interface{}{"type": "string", "airbyte_secret": true},
+			},
+		}},
+	})
+
+	logger := new(testLogger)
+	airbyte, err := New(srv.Endpoint(), WithLogger(logger))
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	// Updates do not carry the definition ID, so it is looked up by the source
+	update := &types.Source{
+		SourceId: source.SourceId,
+		Name:     source.Name,
+		ConnectionConfiguration: map[string]interface{}{
+			"pokemon_name": "snorlax",
+			"pin":          "1234",
+			"password":     "hunter2",
+			"credentials":  map[string]interface{}{"client_id": "client"},
+		},
+	}
+	if _, err := airbyte.UpdateSource(context.Background(), update); err != nil {
+		t.Fatalf("could not update source: %v", err)
+	}
+
+	if len(logger.records) != 2 {
+		t.Fatalf("expected 2 records, got %v", logger.records)
+	}
+
+	debug := logger.records[0]
+	if debug.level != "debug" || debug.attrs["operation"] != "sources.update" {
+		t.Fatalf("unexpected debug record %v", debug)
+	}
+
+	body := fmt.Sprint(debug.attrs["body"])
+	for _, secret := range []string{"1234", "hunter2", "client"} {
+		if strings.Contains(body, secret) {
+			t.Errorf("secret %q was logged: %s", secret, body)
+		}
+	}
+
+	if !strings.Contains(body, "snorlax") {
+		t.Errorf("expected the body to contain the other fields: %s", body)
+	}
+
+	info := logger.records[1]
+	if info.level != "info" || info.attrs["operation"] != "sources.update" || info.attrs["status"] != 200 {
+		t.Errorf("unexpected record %v", info)
+	}
+
+	if _, ok := info.attrs["duration"]; !ok {
+		t.Errorf("expected the duration to be logged")
+	}
+
+	// The update must not have been changed by the redaction
+	if update.ConnectionConfiguration["pin"] != "1234" {
+		t.Errorf("the payload was modified: %v", update.ConnectionConfiguration)
+	}
+}
+
+func TestLoggerLogsErrors(t *testing.T) {
+	srv := airbytetest.NewServer()
+	defer srv.Close()
+
+	logger := new(testLogger)
+	airbyte, err := New(srv.Endpoint(), WithLogger(logger))
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	id := uuid.New()
+	if _, err := airbyte.GetSource(context.Background(), &id); !IsNotFound(err) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+
+	record := logger.records[len(logger.records)-1]
+	if record.level != "warn" || record.attrs["operation"] != "sources.get" || record.attrs["status"] != 404 {
+		t.Fatalf("unexpected record %v", record)
+	}
+
+	if id, _ := record.attrs["error_id"].(string); id == "" {
+		t.Errorf("expected the error ID to be logged")
+	}
+}
+
+func TestRedactSecretKeys(t *testing.T) {
+	value := map[string]interface{}{
+		"name":          "source",
+		"access_token":  "token",
+		"nested":        []interface{}{map[string]interface{}{"DB_PASSWORD": "password"}},
+		"client_secret": nil,
+	}
+
+	redactSecretKeys(value)
+
+	if value["name"] != "source" || value["access_token"] != RedactedSecret || value["client_secret"] != nil {
+		t.Errorf("unexpected redaction %v", value)
+	}
+
+	if nested := value["nested"].([]interface{})[0].(map[string]interface{}); nested["DB_PASSWORD"] != RedactedSecret {
+		t.Errorf("unexpected nested redaction %v", nested)
+	}
+}
+
+// Discards the debug records without formatting them
+type infoLogger struct {
+	testLogger
+}
+
+func (l *infoLogger) DebugContext(ctx context.Context, msg string, args ...interface{}) {}
+
+func TestLoggerSkipsDiscardedBodies(t *testing.T) {
+	srv := airbytetest.NewServer()
+	defer srv.Close()
+
+	unlogged, err := New(srv.Endpoint())
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	conn := createTestConnection(t, unlogged)
+	source, err := unlogged.GetSource(context.Background(), conn.SourceID)
+	if err != nil {
+		t.Fatalf("could not get source: %v", err)
+	}
+
+	var paths []string
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		paths = append(paths, req.URL.Path)
+		return http.DefaultTransport.RoundTrip(req)
+	})
+
+	logger := new(infoLogger)
+	airbyte, err := New(srv.Endpoint(), WithLogger(logger), WithTransport(transport))
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	source.SourceDefinitionId = nil
+	if _, err := airbyte.UpdateSource(context.Background(), source); err != nil {
+		t.Fatalf("could not update source: %v", err)
+	}
+
+	if len(paths) != 1 || !strings.HasSuffix(paths[0], "/v1/sources/update") {
+		t.Fatalf("expected only the update request, got %v", paths)
+	}
+
+	if len(logger.records) != 1 || logger.records[0].level != "info" {
+		t.Fatalf("unexpected records %v", logger.records)
+	}
+}
+
+func TestRedactedBodyWithoutSpecification(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	airbyte, err := New(srv.URL + "/api")
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	l := &callLogger{client: airbyte, specs: make(map[uuid.UUID]map[string]interface{}), definitions: make(map[uuid.UUID]uuid.UUID)}
+	id := uuid.New()
+	body := &redactedBody{ctx: context.Background(), logger: l, payload: &types.Source{
+		SourceDefinitionId:      &id,
+		Name:                    "source",
+		ConnectionConfiguration: map[string]interface{}{"pin": "1234"},
+	}}
+
+	data, err := json.Marshal(map[string]interface{}{"body": body})
+	if err != nil {
+		t.Fatalf("could not encode body: %v", err)
+	}
+
+	if string(data) != `{"body":{"connectionConfiguration":"**********","name":"source","sourceDefinitionId":"`+id.String()+`"}}` {
+		t.Fatalf("unexpected body %s", data)
+	}
+}
+
+func TestRedactedBodyAfterCancel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.Context().Err(); err != nil {
+			t.Errorf("specification request canceled: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"connectionSpecification":{"properties":{"pin":{"type":"string","airbyte_secret":true}}}}`))
+	}))
+	defer srv.Close()
+
+	airbyte, err := New(srv.URL + "/api")
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	// The record is formatted after the call returned and canceled its context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	l := &callLogger{client: airbyte, specs: make(map[uuid.UUID]map[string]interface{}), definitions: make(map[uuid.UUID]uuid.UUID)}
+	id := uuid.New()
+	body := &redactedBody{ctx: ctx, logger: l, payload: &types.Source{
+		SourceDefinitionId:      &id,
+		ConnectionConfiguration: map[string]interface{}{"pin": "1234", "host": "localhost"},
+	}}
+
+	if body.String() != `{"connectionConfiguration":{"host":"localhost","pin":"**********"},"sourceDefinitionId":"`+id.String()+`"}` {
+		t.Fatalf("unexpected body %s", body)
+	}
+}
@@ -0,0 +1,50 @@
+package airbytesdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// The status class of calls that did not receive a response
+const StatusClassError = "error"
+
+// A MetricsCollector records the outcome of every API call of a client
+type MetricsCollector interface {
+	// ObserveCall records a call of the operation, such as "sources.create".
+	// The status class is 2xx, 3xx, 4xx or 5xx for calls with a response and StatusClassError for the rest
+	ObserveCall(operation, statusClass string, duration time.Duration)
+}
+
+// WithMetrics records every API call with the given collector.
+// The duration includes the retries of the call
+func WithMetrics(collector MetricsCollector) Option {
+	return func(c *Client) {
+		c.interceptors = append(c.interceptors, func(ctx context.Context, call *Call, next Invoker) (*http.Response, error) {
+			start := time.Now()
+			res, err := next(ctx, call)
+			collector.ObserveCall(call.Operation, statusClass(res, err), time.Since(start))
+			return res, err
+		})
+	}
+}
+
+// Returns the status class of the response or error of a call
+func statusClass(res *http.Response, err error) string {
+	status := 0
+	var apiErr *APIError
+	switch {
+	case err == nil && res != nil:
+		status = res.StatusCode
+	case errors.As(err, &apiErr):
+		status = apiErr.StatusCode
+	}
+
+	if status < 100 || status >= 600 {
+		return StatusClassError
+	}
+
+	return strconv.Itoa(status/100) + "xx"
+}
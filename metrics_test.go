@@ -0,0 +1,85 @@
+package airbytesdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+type observation struct {
+	operation   string
+	statusClass string
+	duration    time.Duration
+}
+
+type testCollector struct {
+	mu           sync.Mutex
+	observations []observation
+}
+
+func (c *testCollector) ObserveCall(operation, statusClass string, duration time.Duration) {
+	c.mu.Lock()
+	c.observations = append(c.observations, observation{operation, statusClass, duration})
+	c.mu.Unlock()
+}
+
+func TestMetrics(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/sources/get" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Write([]byte(`{"workspaces":[]}`))
+	}))
+	defer srv.Close()
+
+	collector := new(testCollector)
+	airbyte, err := New(srv.URL+"/api", WithMetrics(collector))
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	if _, err := airbyte.ListWorkspaces(context.Background()); err != nil {
+		t.Fatalf("could not list workspaces: %v", err)
+	}
+
+	if _, err := airbyte.GetSource(context.Background(), nil); !IsNotFound(err) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+
+	want := []observation{{"workspaces.list", "2xx", 0}, {"sources.get", "4xx", 0}}
+	if len(collector.observations) != len(want) {
+		t.Fatalf("unexpected observations %v", collector.observations)
+	}
+
+	for i, o := range collector.observations {
+		if o.operation != want[i].operation || o.statusClass != want[i].statusClass || o.duration <= 0 {
+			t.Errorf("unexpected observation %v, want %v", o, want[i])
+		}
+	}
+}
+
+func TestStatusClass(t *testing.T) {
+	tests := []struct {
+		res  *http.Response
+		err  error
+		want string
+	}{
+		{res: &http.Response{StatusCode: http.StatusOK}, want: "2xx"},
+		{err: &APIError{StatusCode: http.StatusConflict}, want: "4xx"},
+		{err: &APIError{StatusCode: http.StatusBadGateway}, want: "5xx"},
+		{err: errors.New("connection refused"), want: StatusClassError},
+		{err: context.Canceled, want: StatusClassError},
+	}
+
+	for _, test := range tests {
+		if got := statusClass(test.res, test.err); got != test.want {
+			t.Errorf("statusClass(%v, %v) = %q, want %q", test.res, test.err, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package airbytesdk
+
+import (
+	"context"
+
+	"github.com/evris99/airbyte-sdk/types"
+	"github.com/google/uuid"
+)
+
+// CreateOperation creates a new operation in a workspace
+func (c *Client) CreateOperation(ctx context.Context, operation *types.Operation) (*types.Operation, error) {
+	u, err := appendToURL(c.endpoint, "/v1/operations/create")
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.makeRequest(ctx, u, operation)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	return types.OperationFromJSON(res.Body)
+}
+
+// UpdateOperation updates the name and operator configuration of an operation
+func (c *Client) UpdateOperation(ctx context.Context, operation *types.Operation) (*types.Operation, error) {
+	u, err := appendToURL(c.endpoint, "/v1/operations/update")
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]interface{})
+	data["operationId"] = operation.OperationId
+	data["name"] = operation.Name
+	data["operatorConfiguration"] = operation.OperatorConfiguration
+
+	res, err := c.makeRequest(ctx, u, data)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	return types.OperationFromJSON(res.Body)
+}
+
+// GetOperation returns the operation with the given ID
+func (c *Client) GetOperation(ctx context.Context, id *uuid.UUID) (*types.Operation, error) {
+	u, err := appendToURL(c.endpoint, "/v1/operations/get")
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]*uuid.UUID)
+	data["operationId"] = id
+
+	res, err := c.makeRequest(ctx, u, data)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	return types.OperationFromJSON(res.Body)
+}
+
+// ListOperations returns the operations of the connection with the given ID
+func (c *Client) ListOperations(ctx context.Context, connectionID *uuid.UUID) ([]types.Operation, error) {
+	u, err := appendToURL(c.endpoint, "/v1/operations/list")
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]*uuid.UUID)
+	data["connectionId"] = connectionID
+
+	res, err := c.makeRequest(ctx, u, data)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	return types.OperationsFromJSON(res.Body)
+}
+
+// DeleteOperation deletes the operation with the given ID
+func (c *Client) DeleteOperation(ctx context.Context, id *uuid.UUID) error {
+	u, err := appendToURL(c.endpoint, "/v1/operations/delete")
+	if err != nil {
+		return err
+	}
+
+	data := make(map[string]*uuid.UUID)
+	data["operationId"] = id
+
+	res, err := c.makeRequest(ctx, u, data)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	return nil
+}
+
+// CheckOperation checks whether the given operator configuration is valid
+func (c *Client) CheckOperation(ctx context.Context, config *types.OperatorConfiguration) (*types.OperationCheck, error) {
+	u, err := appendToURL(c.endpoint, "/v1/operations/check")
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.makeRequest(ctx, u, config)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	return types.OperationCheckFromJSON(res.Body)
+}
@@ -0,0 +1,87 @@
+package airbytesdk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/evris99/airbyte-sdk/airbytetest"
+	"github.com/evris99/airbyte-sdk/types"
+	"github.com/google/uuid"
+)
+
+func TestOperations(t *testing.T) {
+	srv := airbytetest.NewServer()
+	defer srv.Close()
+
+	airbyte, err := New(srv.Endpoint())
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	ctx := context.Background()
+	workspace, err := airbyte.CreateWorkspace(ctx, &types.Workspace{Name: "test"})
+	if err != nil {
+		t.Fatalf("could not create workspace: %v", err)
+	}
+
+	dbt := &types.OperatorConfiguration{
+		OperatorType: types.Dbt,
+		Dbt: &types.OperatorDbt{
+			GitRepoUrl:    "https://github.com/example/dbt-project.git",
+			GitRepoBranch: "main",
+			DockerImage:   "fishtownanalytics/dbt:1.0.0",
+			DbtArguments:  "run --select marts",
+		},
+	}
+
+	check, err := airbyte.CheckOperation(ctx, dbt)
+	if err != nil || check.Status != types.Succeeded {
+		t.Fatalf("expected the operator to be valid, got %v and %v", check, err)
+	}
+
+	check, err = airbyte.CheckOperation(ctx, &types.OperatorConfiguration{OperatorType: types.Dbt, Dbt: &types.OperatorDbt{}})
+	if err != nil || check.Status != types.Failed {
+		t.Fatalf("expected the operator to be invalid, got %v and %v", check, err)
+	}
+
+	operation, err := airbyte.CreateOperation(ctx, &types.Operation{
+		WorkspaceId:           workspace.WorkspaceId,
+		Name:                  "transform",
+		OperatorConfiguration: dbt,
+	})
+	if err != nil {
+		t.Fatalf("could not create operation: %v", err)
+	}
+
+	operation.Name = "normalize"
+	operation.OperatorConfiguration = &types.OperatorConfiguration{
+		OperatorType:  types.Normalization,
+		Normalization: &types.OperatorNormalization{Option: types.BasicNormalization},
+	}
+
+	if _, err := airbyte.UpdateOperation(ctx, operation); err != nil {
+		t.Fatalf("could not update operation: %v", err)
+	}
+
+	found, err := airbyte.GetOperation(ctx, operation.OperationId)
+	if err != nil {
+		t.Fatalf("could not get operation: %v", err)
+	}
+
+	if found.Name != "normalize" || found.OperatorConfiguration.OperatorType != types.Normalization {
+		t.Fatalf("operation was not updated: %+v", found)
+	}
+
+	if err := airbyte.DeleteOperation(ctx, operation.OperationId); err != nil {
+		t.Fatalf("could not delete operation: %v", err)
+	}
+
+	if _, err := airbyte.GetOperation(ctx, operation.OperationId); !IsNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+
+	id := uuid.New()
+	if _, err := airbyte.ListOperations(ctx, &id); !IsNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package airbytesdk
+
+import (
+	"net/http"
+	"time"
+)
+
+// The header carrying the ID of a request
+const requestIDHeader = "X-Request-Id"
+
+// An Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used to make requests. A nil client keeps the default one
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Client) {
+		if client != nil {
+			c.HttpClient = client
+		}
+	}
+}
+
+// WithTransport sets the RoundTripper used to make requests.
+// The HTTP client is copied so a client given with WithHTTPClient is not modified
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) {
+		var client http.Client
+		if c.HttpClient != nil {
+			client = *c.HttpClient
+		}
+		client.Transport = transport
+		c.HttpClient = &client
+	}
+}
+
+// WithTimeout limits the duration of every request, including reading its response
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithUserAgent sets the User-Agent header of every request
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithHeader adds a header to every request
+func WithHeader(key, value string) Option {
+	return func(c *Client) {
+		if c.headers == nil {
+			c.headers = make(http.Header)
+		}
+		c.headers.Add(key, value)
+	}
+}
+
+// WithHeaders adds the given headers to every request
+func WithHeaders(headers http.Header) Option {
+	return func(c *Client) {
+		for key, values := range headers {
+			for _, value := range values {
+				WithHeader(key, value)(c)
+			}
+		}
+	}
+}
+
+// WithRequestIDGenerator sets the X-Request-Id header of every request to a value returned by the generator
+func WithRequestIDGenerator(generator func() string) Option {
+	return func(c *Client) {
+		c.requestID = generator
+	}
+}
@@ -0,0 +1,100 @@
+package airbytesdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRequestOptions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") != "test-agent" {
+			t.Errorf("unexpected user agent %q", r.Header.Get("User-Agent"))
+		}
+
+		if r.Header.Get("X-Tenant") != "acme" {
+			t.Errorf("unexpected tenant header %q", r.Header.Get("X-Tenant"))
+		}
+
+		if r.Header.Get("X-Request-Id") != "request-1" {
+			t.Errorf("unexpected request ID %q", r.Header.Get("X-Request-Id"))
+		}
+
+		w.Write([]byte(`{"workspaces":[]}`))
+	}))
+	defer srv.Close()
+
+	airbyte, err := New(srv.URL+"/api",
+		WithUserAgent("test-agent"),
+		WithHeader("X-Tenant", "acme"),
+		WithRequestIDGenerator(func() string { return "request-1" }),
+	)
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	if _, err := airbyte.ListWorkspaces(context.Background()); err != nil {
+		t.Fatalf("could not list workspaces: %v", err)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	airbyte, err := New(srv.URL+"/api", WithTimeout(10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	if _, err := airbyte.ListWorkspaces(context.Background()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestWithTransport(t *testing.T) {
+	called := false
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		called = true
+		return nil, errors.New("transport error")
+	})
+
+	httpClient := &http.Client{}
+	airbyte, err := New("http://localhost:8000/api", WithHTTPClient(httpClient), WithTransport(transport))
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	if _, err := airbyte.ListWorkspaces(context.Background()); err == nil || !called {
+		t.Fatal("expected the request to go through the custom transport")
+	}
+
+	if httpClient.Transport != nil {
+		t.Fatal("the given HTTP client should not be modified")
+	}
+
+	// A nil HTTP client keeps the default one
+	called = false
+	airbyte, err = New("http://localhost:8000/api", WithHTTPClient(nil), WithTransport(transport))
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	if _, err := airbyte.ListWorkspaces(context.Background()); err == nil || !called {
+		t.Fatal("expected the request to go through the custom transport")
+	}
+}
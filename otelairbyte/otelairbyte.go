@@ -0,0 +1,234 @@
+// Package otelairbyte traces the calls of an airbyte client with OpenTelemetry.
+//
+// Every API call creates a client span named after its operation, such as "sources.create",
+// with the attributes of the HTTP semantic conventions and the IDs of the airbyte resources involved.
+// The trace context is propagated to the server in the request headers,
+// so calls made with the context of a parent span are part of the same trace:
+//
+//	client, err := airbytesdk.New(endpoint, airbytesdk.WithInterceptors(otelairbyte.Interceptor()))
+//
+//	ctx, span := tracer.Start(ctx, "provision")
+//	defer span.End()
+//	source, err := client.CreateSource(ctx, source)
+//	...
+package otelairbyte
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	airbytesdk "github.com/evris99/airbyte-sdk"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// The name of the instrumentation scope
+const instrumentationName = "github.com/evris99/airbyte-sdk/otelairbyte"
+
+// The attributes set on the spans besides the HTTP semantic conventions
+const (
+	OperationKey     = attribute.Key("airbyte.operation")
+	WorkspaceIDKey   = attribute.Key("airbyte.workspace.id")
+	SourceIDKey      = attribute.Key("airbyte.source.id")
+	DestinationIDKey = attribute.Key("airbyte.destination.id")
+	ConnectionIDKey  = attribute.Key("airbyte.connection.id")
+	OperationIDKey   = attribute.Key("airbyte.operation.id")
+	JobIDKey         = attribute.Key("airbyte.job.id")
+)
+
+// The maximum size of a response body that is read for the IDs of the created resources
+const maxResponseSize = 1 << 20
+
+// The attributes of the resource IDs by their JSON field name
+var idKeys = map[string]attribute.Key{
+	"workspaceId":   WorkspaceIDKey,
+	"sourceId":      SourceIDKey,
+	"destinationId": DestinationIDKey,
+	"connectionId":  ConnectionIDKey,
+	"operationId":   OperationIDKey,
+}
+
+type config struct {
+	provider   trace.TracerProvider
+	propagator propagation.TextMapPropagator
+}
+
+// An Option configures the interceptor
+type Option func(*config)
+
+// WithTracerProvider sets the provider of the tracer. The global provider is used by default
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.provider = provider
+	}
+}
+
+// WithPropagator sets the propagator that injects the trace context in the request headers.
+// The global propagator is used by default
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagator = propagator
+	}
+}
+
+// Interceptor returns an interceptor that creates a client span for every call
+func Interceptor(opts ...Option) airbytesdk.Interceptor {
+	cfg := &config{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	if cfg.provider == nil {
+		cfg.provider = otel.GetTracerProvider()
+	}
+
+	if cfg.propagator == nil {
+		cfg.propagator = otel.GetTextMapPropagator()
+	}
+
+	tracer := cfg.provider.Tracer(instrumentationName)
+
+	return func(ctx context.Context, call *airbytesdk.Call, next airbytesdk.Invoker) (*http.Response, error) {
+		ctx, span := tracer.Start(ctx, call.Operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(requestAttributes(call)...),
+		)
+		defer span.End()
+
+		cfg.propagator.Inject(ctx, propagation.HeaderCarrier(call.Header))
+
+		res, err := next(ctx, call)
+		if err != nil {
+			var apiErr *airbytesdk.APIError
+			if errors.As(err, &apiErr) {
+				span.SetAttributes(semconv.HTTPResponseStatusCode(apiErr.StatusCode), semconv.ErrorTypeKey.String(strconv.Itoa(apiErr.StatusCode)))
+			} else {
+				span.SetAttributes(semconv.ErrorTypeKey.String(errorType(err)))
+			}
+
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return res, err
+		}
+
+		span.SetAttributes(semconv.HTTPResponseStatusCode(res.StatusCode))
+
+		// The IDs of created resources and started jobs are only known from the response
+		if createsResource(call.Operation) {
+			span.SetAttributes(responseAttributes(res)...)
+		}
+
+		return res, nil
+	}
+}
+
+// Returns the HTTP and resource attributes of the call
+func requestAttributes(call *airbytesdk.Call) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		OperationKey.String(call.Operation),
+		semconv.HTTPRequestMethodKey.String(call.Method),
+		semconv.URLFull(call.URL.String()),
+		semconv.ServerAddress(call.URL.Hostname()),
+	}
+
+	if port := call.URL.Port(); port != "" {
+		if p, err := strconv.Atoi(port); err == nil {
+			attrs = append(attrs, semconv.ServerPort(p))
+		}
+	} else if call.URL.Scheme == "https" {
+		attrs = append(attrs, semconv.ServerPort(443))
+	} else {
+		attrs = append(attrs, semconv.ServerPort(80))
+	}
+
+	if call.Payload == nil {
+		return attrs
+	}
+
+	data, err := json.Marshal(call.Payload)
+	if err != nil {
+		return attrs
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return attrs
+	}
+
+	attrs = append(attrs, idAttributes(payload)...)
+
+	// The job endpoints identify the job with its numeric ID
+	if strings.HasPrefix(call.Operation, "jobs.") {
+		if id, ok := payload["id"].(float64); ok {
+			attrs = append(attrs, JobIDKey.Int64(int64(id)))
+		}
+	}
+
+	return attrs
+}
+
+// Reads the IDs from the response body and replaces it with the read bytes followed by the rest of the body,
+// so the caller can still decode it. Bodies larger than maxResponseSize are not decoded
+func responseAttributes(res *http.Response) []attribute.KeyValue {
+	body := res.Body
+	data, err := io.ReadAll(io.LimitReader(body, maxResponseSize+1))
+	res.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(data), body), body}
+	if err != nil || len(data) > maxResponseSize {
+		return nil
+	}
+
+	var object map[string]interface{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil
+	}
+
+	attrs := idAttributes(object)
+	if job, ok := object["job"].(map[string]interface{}); ok {
+		if id, ok := job["id"].(float64); ok {
+			attrs = append(attrs, JobIDKey.Int64(int64(id)))
+		}
+	}
+
+	return attrs
+}
+
+func idAttributes(object map[string]interface{}) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	for field, key := range idKeys {
+		if id, ok := object[field].(string); ok && id != "" {
+			attrs = append(attrs, key.String(id))
+		}
+	}
+
+	return attrs
+}
+
+// Reports whether the operation creates a resource or starts a job, returning its ID in the response
+func createsResource(operation string) bool {
+	return strings.HasSuffix(operation, ".create") || operation == "connections.sync" || operation == "connections.reset"
+}
+
+// Returns the type of an error that is not an API error
+func errorType(err error) string {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	}
+
+	return "_OTHER"
+}
@@ -0,0 +1,252 @@
+package otelairbyte
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	airbytesdk "github.com/evris99/airbyte-sdk"
+	"github.com/evris99/airbyte-sdk/airbytetest"
+	"github.com/evris99/airbyte-sdk/types"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newTracerProvider() (*sdktrace.TracerProvider, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	return sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)), exporter
+}
+
+func attributeValue(span tracetest.SpanStub, key attribute.Key) (attribute.Value, bool) {
+	for _, attr := range span.Attributes {
+		if attr.Key == key {
+			return attr.Value, true
+		}
+	}
+
+	return attribute.Value{}, false
+}
+
+func findSpan(t *testing.T, spans tracetest.SpanStubs, name string) tracetest.SpanStub {
+	t.Helper()
+	for _, span := range spans {
+		if span.Name == name {
+			return span
+		}
+	}
+
+	t.Fatalf("could not find span %q", name)
+	return tracetest.SpanStub{}
+}
+
+func TestWorkflowTrace(t *testing.T) {
+	srv := airbytetest.NewServer()
+	defer srv.Close()
+
+	provider, exporter := newTracerProvider()
+	airbyte, err := airbytesdk.New(srv.Endpoint(), airbytesdk.WithInterceptors(Interceptor(WithTracerProvider(provider))))
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "provision")
+
+	workspace, err := airbyte.CreateWorkspace(ctx, &types.Workspace{Name: "traced"})
+	if err != nil {
+		t.Fatalf("could not create workspace: %v", err)
+	}
+
+	sourceDefinitions, err := airbyte.ListSourceDefinitions(ctx)
+	if err != nil {
+		t.Fatalf("could not list source definitions: %v", err)
+	}
+
+	source, err := airbyte.CreateSource(ctx, &types.Source{
+		SourceDefinitionId:      sourceDefinitions[0].SourceDefinitionId,
+		WorkspaceId:             workspace.WorkspaceId,
+		Name:                    "source",
+		ConnectionConfiguration: map[string]interface{}{},
+	})
+	if err != nil {
+		t.Fatalf("could not create source: %v", err)
+	}
+
+	if _, err := airbyte.DiscoverSourceSchema(ctx, source.SourceId, false); err != nil {
+		t.Fatalf("could not discover schema: %v", err)
+	}
+
+	destinationDefinitions, err := airbyte.ListDestinationDefinitions(ctx)
+	if err != nil {
+		t.Fatalf("could not list destination definitions: %v", err)
+	}
+
+	destination, err := airbyte.CreateDestination(ctx, &types.Destination{
+		DestinationDefinitionId: destinationDefinitions[0].DestinationDefinitionId,
+		WorkspaceId:             workspace.WorkspaceId,
+		Name:                    "destination",
+		ConnectionConfiguration: map[string]interface{}{},
+	})
+	if err != nil {
+		t.Fatalf("could not create destination: %v", err)
+	}
+
+	conn, err := airbyte.CreateConnection(ctx, &types.Connection{
+		Name:          "connection",
+		SourceID:      source.SourceId,
+		DestinationId: destination.DestinationId,
+		Status:        types.Active,
+	})
+	if err != nil {
+		t.Fatalf("could not create connection: %v", err)
+	}
+
+	job, err := airbyte.SyncConnection(ctx, conn.ConnectionId)
+	if err != nil {
+		t.Fatalf("could not sync connection: %v", err)
+	}
+
+	parent.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 9 {
+		t.Fatalf("expected 9 spans, got %d", len(spans))
+	}
+
+	for _, span := range spans {
+		if span.SpanContext.TraceID() != parent.SpanContext().TraceID() {
+			t.Errorf("span %q is not part of the trace", span.Name)
+		}
+
+		if span.Name != "provision" && span.SpanKind != trace.SpanKindClient {
+			t.Errorf("span %q is not a client span", span.Name)
+		}
+	}
+
+	create := findSpan(t, spans, "sources.create")
+	if v, _ := attributeValue(create, SourceIDKey); v.AsString() != source.SourceId.String() {
+		t.Errorf("unexpected source ID %q", v.AsString())
+	}
+
+	if v, _ := attributeValue(create, WorkspaceIDKey); v.AsString() != workspace.WorkspaceId.String() {
+		t.Errorf("unexpected workspace ID %q", v.AsString())
+	}
+
+	if v, _ := attributeValue(create, "http.request.method"); v.AsString() != http.MethodPost {
+		t.Errorf("unexpected method %q", v.AsString())
+	}
+
+	if v, _ := attributeValue(create, "http.response.status_code"); v.AsInt64() != http.StatusOK {
+		t.Errorf("unexpected status code %d", v.AsInt64())
+	}
+
+	sync := findSpan(t, spans, "connections.sync")
+	if v, _ := attributeValue(sync, ConnectionIDKey); v.AsString() != conn.ConnectionId.String() {
+		t.Errorf("unexpected connection ID %q", v.AsString())
+	}
+
+	if v, _ := attributeValue(sync, JobIDKey); v.AsInt64() != job.Job.ID {
+		t.Errorf("unexpected job ID %d", v.AsInt64())
+	}
+}
+
+func TestPropagation(t *testing.T) {
+	var traceparent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.Write([]byte(`{"workspaces":[]}`))
+	}))
+	defer srv.Close()
+
+	provider, exporter := newTracerProvider()
+	airbyte, err := airbytesdk.New(srv.URL+"/api", airbytesdk.WithInterceptors(
+		Interceptor(WithTracerProvider(provider), WithPropagator(propagation.TraceContext{})),
+	))
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	if _, err := airbyte.ListWorkspaces(context.Background()); err != nil {
+		t.Fatalf("could not list workspaces: %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+
+	want := "00-" + spans[0].SpanContext.TraceID().String() + "-" + spans[0].SpanContext.SpanID().String() + "-01"
+	if traceparent != want {
+		t.Fatalf("expected traceparent %q, got %q", want, traceparent)
+	}
+}
+
+func TestErrorSpan(t *testing.T) {
+	srv := airbytetest.NewServer()
+	defer srv.Close()
+
+	provider, exporter := newTracerProvider()
+	airbyte, err := airbytesdk.New(srv.Endpoint(), airbytesdk.WithInterceptors(Interceptor(WithTracerProvider(provider))))
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	id := uuid.New()
+	if _, err := airbyte.GetConnection(context.Background(), &id); !airbytesdk.IsNotFound(err) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+
+	span := findSpan(t, exporter.GetSpans(), "connections.get")
+	if span.Status.Code != codes.Error {
+		t.Errorf("expected an error status, got %v", span.Status)
+	}
+
+	if v, _ := attributeValue(span, "http.response.status_code"); v.AsInt64() != http.StatusNotFound {
+		t.Errorf("unexpected status code %d", v.AsInt64())
+	}
+
+	if v, _ := attributeValue(span, ConnectionIDKey); v.AsString() != id.String() {
+		t.Errorf("unexpected connection ID %q", v.AsString())
+	}
+}
+
+func TestLargeCreateResponse(t *testing.T) {
+	connectionID := uuid.New()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(&types.Connection{
+			ConnectionId: &connectionID,
+			Name:         "large",
+			SyncCatalog: &types.SyncCatalogType{Streams: []types.StreamAndConfiguration{{
+				Stream: &types.StreamType{Name: "stream", JsonSchema: map[string]interface{}{"description": strings.Repeat("a", 2*maxResponseSize)}},
+			}}},
+		})
+	}))
+	defer srv.Close()
+
+	provider, exporter := newTracerProvider()
+	airbyte, err := airbytesdk.New(srv.URL+"/api", airbytesdk.WithInterceptors(Interceptor(WithTracerProvider(provider))))
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	conn, err := airbyte.CreateConnection(context.Background(), &types.Connection{Name: "large"})
+	if err != nil {
+		t.Fatalf("could not create connection: %v", err)
+	}
+
+	if *conn.ConnectionId != connectionID || len(conn.SyncCatalog.Streams[0].Stream.JsonSchema["description"].(string)) != 2*maxResponseSize {
+		t.Fatal("the response was not decoded completely")
+	}
+
+	span := findSpan(t, exporter.GetSpans(), "connections.create")
+	if _, ok := attributeValue(span, ConnectionIDKey); ok {
+		t.Error("the IDs of responses larger than the limit should not be read")
+	}
+}
@@ -0,0 +1,106 @@
+// Package prommetrics exports the metrics of an airbyte client to Prometheus.
+//
+// The collector counts the API calls and observes their latency, labeled by operation and status class:
+//
+//	collector := prommetrics.New()
+//	prometheus.MustRegister(collector)
+//
+//	client, err := airbytesdk.New(endpoint, airbytesdk.WithMetrics(collector))
+package prommetrics
+
+import (
+	"time"
+
+	airbytesdk "github.com/evris99/airbyte-sdk"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// The labels of the metrics
+var labels = []string{"operation", "status_class"}
+
+// A Collector records the calls of airbyte clients and exposes them as Prometheus metrics.
+// It can be shared by multiple clients
+type Collector struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+type config struct {
+	namespace   string
+	buckets     []float64
+	constLabels prometheus.Labels
+}
+
+// An Option configures a Collector
+type Option func(*config)
+
+// WithNamespace sets the namespace of the metric names. The default is airbyte
+func WithNamespace(namespace string) Option {
+	return func(c *config) {
+		c.namespace = namespace
+	}
+}
+
+// WithBuckets sets the buckets of the latency histogram in seconds. The default is prometheus.DefBuckets
+func WithBuckets(buckets []float64) Option {
+	return func(c *config) {
+		c.buckets = buckets
+	}
+}
+
+// WithConstLabels adds labels with fixed values to the metrics, for example to tell apart deployments
+func WithConstLabels(labels prometheus.Labels) Option {
+	return func(c *config) {
+		c.constLabels = labels
+	}
+}
+
+// New creates a collector with the metrics <namespace>_client_requests_total
+// and <namespace>_client_request_duration_seconds
+func New(opts ...Option) *Collector {
+	cfg := &config{namespace: "airbyte", buckets: prometheus.DefBuckets}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   cfg.namespace,
+			Subsystem:   "client",
+			Name:        "requests_total",
+			Help:        "The number of airbyte API calls by operation and status class.",
+			ConstLabels: cfg.constLabels,
+		}, labels),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   cfg.namespace,
+			Subsystem:   "client",
+			Name:        "request_duration_seconds",
+			Help:        "The latency of airbyte API calls in seconds, including retries.",
+			Buckets:     cfg.buckets,
+			ConstLabels: cfg.constLabels,
+		}, labels),
+	}
+}
+
+// ObserveCall implements airbytesdk.MetricsCollector
+func (c *Collector) ObserveCall(operation, statusClass string, duration time.Duration) {
+	c.requests.WithLabelValues(operation, statusClass).Inc()
+	c.duration.WithLabelValues(operation, statusClass).Observe(duration.Seconds())
+}
+
+// Describe implements prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.requests.Describe(ch)
+	c.duration.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.requests.Collect(ch)
+	c.duration.Collect(ch)
+}
+
+var (
+	_ airbytesdk.MetricsCollector = (*Collector)(nil)
+	_ prometheus.Collector        = (*Collector)(nil)
+)
@@ -0,0 +1,52 @@
+package prommetrics
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	airbytesdk "github.com/evris99/airbyte-sdk"
+	"github.com/evris99/airbyte-sdk/airbytetest"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCollector(t *testing.T) {
+	srv := airbytetest.NewServer()
+	defer srv.Close()
+
+	collector := New(WithConstLabels(prometheus.Labels{"deployment": "test"}))
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collector)
+
+	airbyte, err := airbytesdk.New(srv.Endpoint(), airbytesdk.WithMetrics(collector))
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := airbyte.ListWorkspaces(context.Background()); err != nil {
+			t.Fatalf("could not list workspaces: %v", err)
+		}
+	}
+
+	id := uuid.New()
+	if _, err := airbyte.GetSource(context.Background(), &id); !airbytesdk.IsNotFound(err) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+
+	expected := `
+# HELP airbyte_client_requests_total The number of airbyte API calls by operation and status class.
+# TYPE airbyte_client_requests_total counter
+airbyte_client_requests_total{deployment="test",operation="sources.get",status_class="4xx"} 1
+airbyte_client_requests_total{deployment="test",operation="workspaces.list",status_class="2xx"} 2
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(expected), "airbyte_client_requests_total"); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := testutil.CollectAndCount(collector, "airbyte_client_request_duration_seconds"); n != 2 {
+		t.Fatalf("expected 2 histograms, got %d", n)
+	}
+}
@@ -0,0 +1,150 @@
+package airbytesdk
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// The operations that start connector containers on the server to check a configuration or discover a schema
+var ConnectorOperations = []string{
+	"sources.check_connection",
+	"sources.check_connection_for_update",
+	"sources.discover_schema",
+	"destinations.check_connection",
+	"destinations.check_connection_for_update",
+}
+
+// The limits on the requests of a client
+type limits struct {
+	bucket *tokenBucket
+	// Limits the requests of all operations
+	inFlight chan struct{}
+	// Limits the requests of the operations in the key, shared between operations of the same class
+	operations map[string]chan struct{}
+}
+
+// WithRateLimit limits the requests to the given number per second, allowing bursts of up to burst requests.
+// Every attempt of a retried request counts as a request
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(c *Client) {
+		if requestsPerSecond <= 0 {
+			c.limits.bucket = nil
+			return
+		}
+
+		c.limits.bucket = newTokenBucket(requestsPerSecond, burst)
+	}
+}
+
+// WithMaxInFlight limits the number of requests that are in progress at the same time.
+// A request is in progress until its response headers arrive, so reading a response body,
+// such as iterating over a list, does not hold a slot
+func WithMaxInFlight(n int) Option {
+	return func(c *Client) {
+		c.limits.inFlight = nil
+		if n > 0 {
+			c.limits.inFlight = make(chan struct{}, n)
+		}
+	}
+}
+
+// WithMaxInFlightOperations limits the number of requests of the given operations,
+// such as ConnectorOperations, that are in progress at the same time.
+// The operations share the limit, which applies in addition to the one of WithMaxInFlight
+func WithMaxInFlightOperations(n int, operations ...string) Option {
+	return func(c *Client) {
+		if n <= 0 {
+			return
+		}
+
+		if c.limits.operations == nil {
+			c.limits.operations = make(map[string]chan struct{})
+		}
+
+		slots := make(chan struct{}, n)
+		for _, operation := range operations {
+			c.limits.operations[operation] = slots
+		}
+	}
+}
+
+// Waits until a request of the operation is allowed and returns the function that releases its slots.
+// It returns the error of the context if it is done first
+func (l *limits) acquire(ctx context.Context, operation string) (func(), error) {
+	var acquired []chan struct{}
+	release := func() {
+		for _, slots := range acquired {
+			<-slots
+		}
+	}
+
+	for _, slots := range []chan struct{}{l.operations[operation], l.inFlight} {
+		if slots == nil {
+			continue
+		}
+
+		select {
+		case slots <- struct{}{}:
+			acquired = append(acquired, slots)
+		case <-ctx.Done():
+			release()
+			return nil, ctx.Err()
+		}
+	}
+
+	if l.bucket != nil {
+		if err := l.bucket.wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	return release, nil
+}
+
+// A token bucket that is refilled at a constant rate
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// Takes a token, waiting until it is available. The token is returned if the context is done first
+func (b *tokenBucket) wait(ctx context.Context) error {
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+	tokens := b.tokens
+	b.mu.Unlock()
+
+	if tokens >= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(time.Duration(-tokens / b.rate * float64(time.Second)))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,261 @@
+package airbytesdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/evris99/airbyte-sdk/airbytetest"
+	"github.com/evris99/airbyte-sdk/types"
+	"github.com/google/uuid"
+)
+
+// A server that counts the maximum number of concurrent requests to each path
+type concurrencyServer struct {
+	*httptest.Server
+	mu      sync.Mutex
+	current map[string]int
+	max     map[string]int
+	total   int32
+}
+
+func newConcurrencyServer(delay time.Duration) *concurrencyServer {
+	s := &concurrencyServer{current: make(map[string]int), max: make(map[string]int)}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&s.total, 1)
+		s.mu.Lock()
+		s.current[r.URL.Path]++
+		if s.current[r.URL.Path] > s.max[r.URL.Path] {
+			s.max[r.URL.Path] = s.current[r.URL.Path]
+		}
+		s.mu.Unlock()
+
+		time.Sleep(delay)
+
+		s.mu.Lock()
+		s.current[r.URL.Path]--
+		s.mu.Unlock()
+
+		w.Write([]byte(`{"workspaces":[],"status":"succeeded"}`))
+	}))
+
+	return s
+}
+
+func (s *concurrencyServer) maxConcurrent(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.max[path]
+}
+
+func TestMaxInFlight(t *testing.T) {
+	srv := newConcurrencyServer(20 * time.Millisecond)
+	defer srv.Close()
+
+	airbyte, err := New(srv.URL+"/api", WithMaxInFlight(2))
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := airbyte.ListWorkspaces(context.Background()); err != nil {
+				t.Errorf("could not list workspaces: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := srv.maxConcurrent("/api/v1/workspaces/list"); n != 2 {
+		t.Fatalf("expected 2 concurrent requests, got %d", n)
+	}
+}
+
+func TestMaxInFlightOperations(t *testing.T) {
+	srv := newConcurrencyServer(20 * time.Millisecond)
+	defer srv.Close()
+
+	airbyte, err := New(srv.URL+"/api", WithMaxInFlightOperations(1, ConnectorOperations...))
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			id := uuid.New()
+			if _, err := airbyte.CheckSourceConnection(context.Background(), &id); err != nil {
+				t.Errorf("could not check source: %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			id := uuid.New()
+			if _, err := airbyte.CheckDestinationConnection(context.Background(), &id); err != nil {
+				t.Errorf("could not check destination: %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if _, err := airbyte.ListWorkspaces(context.Background()); err != nil {
+				t.Errorf("could not list workspaces: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	checks := srv.maxConcurrent("/api/v1/sources/check_connection") + srv.maxConcurrent("/api/v1/destinations/check_connection")
+	if checks != 2 {
+		t.Errorf("expected the checks to run one at a time, got %d", checks)
+	}
+
+	if n := srv.maxConcurrent("/api/v1/workspaces/list"); n != 3 {
+		t.Errorf("expected the other operations to be unlimited, got %d", n)
+	}
+}
+
+func TestMaxInFlightCancel(t *testing.T) {
+	unblock := make(chan struct{})
+	var total int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&total, 1) == 1 {
+			<-unblock
+		}
+
+		w.Write([]byte(`{"workspaces":[]}`))
+	}))
+	defer srv.Close()
+
+	airbyte, err := New(srv.URL+"/api", WithMaxInFlight(1))
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	// Hold the only slot with a request the server does not answer
+	done := make(chan error)
+	go func() {
+		_, err := airbyte.ListWorkspaces(context.Background())
+		done <- err
+	}()
+
+	for atomic.LoadInt32(&total) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := airbyte.ListWorkspaces(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+
+	close(unblock)
+	if err := <-done; err != nil {
+		t.Fatalf("could not list workspaces: %v", err)
+	}
+
+	if _, err := airbyte.ListWorkspaces(context.Background()); err != nil {
+		t.Fatalf("could not list workspaces after the slot was released: %v", err)
+	}
+
+	if n := atomic.LoadInt32(&total); n != 2 {
+		t.Fatalf("expected 2 requests to reach the server, got %d", n)
+	}
+}
+
+func TestMaxInFlightIterator(t *testing.T) {
+	srv := airbytetest.NewServer()
+	defer srv.Close()
+
+	airbyte, err := New(srv.Endpoint(), WithMaxInFlight(1))
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	for _, name := range []string{"first", "second"} {
+		if _, err := airbyte.CreateWorkspace(context.Background(), &types.Workspace{Name: name}); err != nil {
+			t.Fatalf("could not create workspace: %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// Calls made while iterating must not wait for the open body of the iterator
+	it := airbyte.IterateWorkspaces(ctx)
+	defer it.Close()
+
+	found := 0
+	for it.Next() {
+		if _, err := airbyte.FindWorkspaceByID(ctx, it.Workspace().WorkspaceId); err != nil {
+			t.Fatalf("could not find workspace: %v", err)
+		}
+		found++
+	}
+
+	if err := it.Err(); err != nil {
+		t.Fatalf("could not iterate workspaces: %v", err)
+	}
+
+	if found != 2 {
+		t.Fatalf("expected 2 workspaces, got %d", found)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	srv := newConcurrencyServer(0)
+	defer srv.Close()
+
+	airbyte, err := New(srv.URL+"/api", WithRateLimit(20, 2))
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if _, err := airbyte.ListWorkspaces(context.Background()); err != nil {
+			t.Fatalf("could not list workspaces: %v", err)
+		}
+	}
+
+	// The burst allows 2 requests immediately and the other 2 wait 50ms each
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Fatalf("expected the requests to be limited, took %v", elapsed)
+	}
+}
+
+func TestRateLimitCancel(t *testing.T) {
+	srv := newConcurrencyServer(0)
+	defer srv.Close()
+
+	airbyte, err := New(srv.URL+"/api", WithRateLimit(0.5, 1))
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	if _, err := airbyte.ListWorkspaces(context.Background()); err != nil {
+		t.Fatalf("could not list workspaces: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := airbyte.ListWorkspaces(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected the wait to stop with the context, took %v", elapsed)
+	}
+}
@@ -0,0 +1,159 @@
+package airbytesdk
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The value that replaces secrets. Airbyte masks secrets in its responses with the same value
+const RedactedSecret = "**********"
+
+// Returns a copy of the connector configuration with the secrets replaced by RedactedSecret,
+// along with the dot separated paths of the redacted fields. Array items are identified by their index, as in tunnels.0.password.
+// Secrets are the fields the connection specification marks with airbyte_secret and the values airbyte already masked
+func redactConfiguration(config, spec map[string]interface{}) (map[string]interface{}, []string) {
+	var paths []string
+	redacted := redactObject(config, []map[string]interface{}{spec}, "", &paths)
+	sort.Strings(paths)
+	return redacted, paths
+}
+
+func redactObject(config map[string]interface{}, schemas []map[string]interface{}, prefix string, paths *[]string) map[string]interface{} {
+	if config == nil {
+		return nil
+	}
+
+	redacted := make(map[string]interface{}, len(config))
+	for key, value := range config {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		fieldSchemas := propertySchemas(schemas, key)
+		if isSecret(fieldSchemas) || value == RedactedSecret {
+			if value != nil {
+				redacted[key] = RedactedSecret
+				*paths = append(*paths, path)
+			}
+
+			continue
+		}
+
+		redacted[key] = redactValue(value, fieldSchemas, path, paths)
+	}
+
+	return redacted
+}
+
+func redactValue(value interface{}, schemas []map[string]interface{}, path string, paths *[]string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return redactObject(v, schemas, path, paths)
+	case []interface{}:
+		var items []map[string]interface{}
+		for _, schema := range schemas {
+			if item, ok := schema["items"].(map[string]interface{}); ok {
+				items = append(items, item)
+			}
+		}
+
+		// The items are identified by their index in the path
+		redacted := make([]interface{}, len(v))
+		for i := range v {
+			itemPath := path + "." + strconv.Itoa(i)
+			if isSecret(items) && v[i] != nil {
+				redacted[i] = RedactedSecret
+				*paths = append(*paths, itemPath)
+				continue
+			}
+
+			redacted[i] = redactValue(v[i], items, itemPath, paths)
+		}
+
+		return redacted
+	}
+
+	return value
+}
+
+// Returns the schemas of the property with the given name, including the ones declared by oneOf and anyOf options
+func propertySchemas(schemas []map[string]interface{}, name string) []map[string]interface{} {
+	var found []map[string]interface{}
+	for _, schema := range schemas {
+		if properties, ok := schema["properties"].(map[string]interface{}); ok {
+			if property, ok := properties[name].(map[string]interface{}); ok {
+				found = append(found, property)
+			}
+		}
+
+		for _, key := range []string{"oneOf", "anyOf"} {
+			options, _ := schema[key].([]interface{})
+			for _, option := range options {
+				if optionSchema, ok := option.(map[string]interface{}); ok {
+					found = append(found, propertySchemas([]map[string]interface{}{optionSchema}, name)...)
+				}
+			}
+		}
+	}
+
+	return found
+}
+
+func isSecret(schemas []map[string]interface{}) bool {
+	for _, schema := range schemas {
+		if secret, _ := schema["airbyte_secret"].(bool); secret {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Sets the value of the field with the given dot separated path, creating the objects on the way.
+// Keys of arrays are the indexes of their items. Paths to items that do not exist are ignored
+func setConfigurationValue(config map[string]interface{}, path string, value interface{}) {
+	keys := strings.Split(path, ".")
+	var current interface{} = config
+	for i, key := range keys {
+		last := i == len(keys)-1
+
+		switch container := current.(type) {
+		case map[string]interface{}:
+			if last {
+				container[key] = value
+				return
+			}
+
+			if !isContainer(container[key]) {
+				container[key] = make(map[string]interface{})
+			}
+			current = container[key]
+		case []interface{}:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(container) {
+				return
+			}
+
+			if last {
+				container[index] = value
+				return
+			}
+
+			if !isContainer(container[index]) {
+				container[index] = make(map[string]interface{})
+			}
+			current = container[index]
+		}
+	}
+}
+
+func isContainer(value interface{}) bool {
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,63 @@
+package airbytesdk
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRedactConfiguration(t *testing.T) {
+	spec := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"host":     map[string]interface{}{"type": "string"},
+			"password": map[string]interface{}{"type": "string", "airbyte_secret": true},
+			"credentials": map[string]interface{}{
+				"type": "object",
+				"oneOf": []interface{}{
+					map[string]interface{}{"properties": map[string]interface{}{
+						"api_key": map[string]interface{}{"type": "string", "airbyte_secret": true},
+					}},
+					map[string]interface{}{"properties": map[string]interface{}{
+						"client_secret": map[string]interface{}{"type": "string", "airbyte_secret": true},
+						"client_id":     map[string]interface{}{"type": "string"},
+					}},
+				},
+			},
+			"tunnels": map[string]interface{}{
+				"type": "array",
+				"items": map[string]interface{}{"properties": map[string]interface{}{
+					"ssh_key": map[string]interface{}{"type": "string", "airbyte_secret": true},
+				}},
+			},
+		},
+	}
+
+	config := map[string]interface{}{
+		"host":        "db.internal",
+		"password":    "hunter2",
+		"credentials": map[string]interface{}{"client_id": "id", "client_secret": "secret"},
+		"tunnels":     []interface{}{map[string]interface{}{"ssh_key": "key"}},
+		"token":       RedactedSecret,
+	}
+
+	redacted, paths := redactConfiguration(config, spec)
+
+	expected := map[string]interface{}{
+		"host":        "db.internal",
+		"password":    RedactedSecret,
+		"credentials": map[string]interface{}{"client_id": "id", "client_secret": RedactedSecret},
+		"tunnels":     []interface{}{map[string]interface{}{"ssh_key": RedactedSecret}},
+		"token":       RedactedSecret,
+	}
+	if !reflect.DeepEqual(redacted, expected) {
+		t.Fatalf("unexpected redacted configuration: %v", redacted)
+	}
+
+	if !reflect.DeepEqual(paths, []string{"credentials.client_secret", "password", "token", "tunnels.0.ssh_key"}) {
+		t.Fatalf("unexpected redacted paths: %v", paths)
+	}
+
+	if config["password"] != "hunter2" {
+		t.Fatal("the original configuration should not be modified")
+	}
+}
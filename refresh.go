@@ -0,0 +1,241 @@
+package airbytesdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/evris99/airbyte-sdk/types"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrRemovedFields   = errors.New("fields were removed from selected streams")
+	ErrDiscoveryFailed = errors.New("schema discovery failed")
+)
+
+// What to do with streams that appear in a newly discovered catalog
+type NewStreamsPolicy int
+
+const (
+	// Add new streams to the catalog without selecting them
+	IgnoreNewStreams NewStreamsPolicy = iota
+	// Add new streams to the catalog and select them
+	SelectNewStreams
+)
+
+// What to do with fields of selected streams that disappear from a newly discovered catalog
+type RemovedFieldsPolicy int
+
+const (
+	// Fail the refresh and leave the connection unchanged
+	FailOnRemovedFields RemovedFieldsPolicy = iota
+	// Drop the removed fields and update the connection
+	AcceptRemovedFields
+)
+
+// Controls how a newly discovered catalog is merged into the catalog of a connection.
+// The zero value ignores new streams and fails on removed fields
+type CatalogPolicy struct {
+	NewStreams    NewStreamsPolicy
+	RemovedFields RemovedFieldsPolicy
+}
+
+// The result of refreshing the catalog of a connection
+type CatalogRefresh struct {
+	// The updated connection. Nil if nothing changed
+	Connection *types.Connection
+	// The differences between the previous and the discovered catalog
+	Changes *types.CatalogChanges
+}
+
+// RefreshConnectionCatalog discovers the schema of the source of the connection
+// and merges it into the catalog of the connection according to the policy.
+// The configuration of existing streams, such as their selection, sync modes, cursors and aliases, is kept.
+// Removed selected streams count as removed fields. Selected streams must still support their sync mode and have a cursor
+// for incremental syncs, while unselected streams fall back to a sync mode they support
+func (c *Client) RefreshConnectionCatalog(ctx context.Context, connID *uuid.UUID, policy CatalogPolicy) (*CatalogRefresh, error) {
+	conn, err := c.GetConnection(ctx, connID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get connection: %w", err)
+	}
+
+	discovered, err := c.DiscoverSourceSchema(ctx, conn.SourceID, true)
+	if err != nil {
+		return nil, fmt.Errorf("could not discover source schema: %w", err)
+	}
+
+	// A failed discovery has no catalog, which would otherwise look like every stream was removed
+	if discovered.Catalog == nil || (discovered.JobInfo != nil && !discovered.JobInfo.Succeeded) {
+		return nil, ErrDiscoveryFailed
+	}
+
+	current := conn.SyncCatalog
+	if current == nil {
+		current = new(types.SyncCatalogType)
+	}
+
+	changes := types.DiffCatalogs(current, discovered.Catalog)
+	refresh := &CatalogRefresh{Changes: changes}
+	if changes.Empty() {
+		return refresh, nil
+	}
+
+	if policy.RemovedFields == FailOnRemovedFields {
+		if removed := removedSelectedFields(current, changes); len(removed) > 0 {
+			return nil, fmt.Errorf("%w: %s", ErrRemovedFields, strings.Join(removed, ", "))
+		}
+	}
+
+	catalog, err := mergeCatalog(current, discovered.Catalog, changes, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.SyncCatalog = catalog
+	refresh.Connection, err = c.UpdateConnection(ctx, conn)
+	if err != nil {
+		return nil, fmt.Errorf("could not update connection: %w", err)
+	}
+
+	return refresh, nil
+}
+
+// Returns the removed streams and fields of the streams that are selected in the catalog
+func removedSelectedFields(current *types.SyncCatalogType, changes *types.CatalogChanges) []string {
+	var removed []string
+	for _, d := range changes.RemovedStreams {
+		if isSelected(current.Find(d.Name, d.Namespace)) {
+			removed = append(removed, d.String())
+		}
+	}
+
+	for _, s := range changes.UpdatedStreams {
+		if !isSelected(current.Find(s.Stream.Name, s.Stream.Namespace)) {
+			continue
+		}
+
+		for _, f := range s.RemovedFields {
+			removed = append(removed, s.Stream.String()+"."+strings.Join(f.Path, "."))
+		}
+	}
+
+	return removed
+}
+
+// Builds the catalog of the discovered streams, keeping the configuration of the streams that already existed
+func mergeCatalog(current, discovered *types.SyncCatalogType, changes *types.CatalogChanges, policy CatalogPolicy) (*types.SyncCatalogType, error) {
+	merged, err := discovered.Copy()
+	if err != nil {
+		return nil, fmt.Errorf("could not copy catalog: %w", err)
+	}
+
+	for i := range merged.Streams {
+		s := &merged.Streams[i]
+		if s.Stream == nil {
+			continue
+		}
+
+		existing := current.Find(s.Stream.Name, s.Stream.Namespace)
+		if existing == nil || existing.Config == nil {
+			if s.Config == nil {
+				s.Config = &types.Config{SyncMode: types.FullRefresh, DestinationSyncMode: types.Append}
+			}
+
+			s.Config.Selected = policy.NewStreams == SelectNewStreams
+			continue
+		}
+
+		config := *existing.Config
+		if s.Stream.SourceDefinedCursor {
+			config.CursorField = s.Stream.DefaultCursorField
+		}
+
+		if len(s.Stream.SourceDefinedPrimaryKey) > 0 {
+			config.PrimaryKey = s.Stream.SourceDefinedPrimaryKey
+		}
+
+		if config.Selected {
+			if err := checkSyncMode(s.Stream, &config); err != nil {
+				return nil, err
+			}
+
+			if err := checkRemovedKeys(s.Stream, &config, changes); err != nil {
+				return nil, err
+			}
+		} else {
+			fallBackSyncMode(s.Stream, &config)
+		}
+
+		s.Config = &config
+	}
+
+	return merged, nil
+}
+
+// Checks that the stream still supports the sync mode chosen for it and has a cursor for incremental syncs
+func checkSyncMode(stream *types.StreamType, config *types.Config) error {
+	descriptor := types.StreamDescriptor{Name: stream.Name, Namespace: stream.Namespace}
+	if !stream.SupportsSyncMode(config.SyncMode) {
+		return fmt.Errorf("stream %q: sync mode %s: %w", descriptor, config.SyncMode, types.ErrUnsupportedSyncMode)
+	}
+
+	if config.SyncMode == types.Incremental && len(config.CursorField) == 0 && !stream.SourceDefinedCursor {
+		return fmt.Errorf("stream %q: %w", descriptor, types.ErrMissingCursor)
+	}
+
+	return nil
+}
+
+// Switches an unselected stream to full refresh, or to the first mode it supports,
+// when it no longer supports its sync mode or lost the cursor of its incremental sync
+func fallBackSyncMode(stream *types.StreamType, config *types.Config) {
+	if checkSyncMode(stream, config) == nil {
+		return
+	}
+
+	config.SyncMode = types.FullRefresh
+	if !stream.SupportsSyncMode(types.FullRefresh) {
+		config.SyncMode = stream.SupportedSyncModes[0]
+	}
+}
+
+// Checks that the cursor and primary key chosen for the stream were not removed
+func checkRemovedKeys(stream *types.StreamType, config *types.Config, changes *types.CatalogChanges) error {
+	var removed []types.FieldChange
+	for _, s := range changes.UpdatedStreams {
+		if s.Stream.Name == stream.Name && s.Stream.Namespace == stream.Namespace {
+			removed = s.RemovedFields
+		}
+	}
+
+	descriptor := types.StreamDescriptor{Name: stream.Name, Namespace: stream.Namespace}
+	if config.SyncMode == types.Incremental && isRemoved(config.CursorField, removed) {
+		return fmt.Errorf("stream %q: cursor %v: %w", descriptor, config.CursorField, types.ErrUnknownField)
+	}
+
+	if config.DestinationSyncMode == types.AppendDedup {
+		for _, key := range config.PrimaryKey {
+			if isRemoved(key, removed) {
+				return fmt.Errorf("stream %q: primary key %v: %w", descriptor, key, types.ErrUnknownField)
+			}
+		}
+	}
+
+	return nil
+}
+
+func isRemoved(path []string, removed []types.FieldChange) bool {
+	for _, f := range removed {
+		if strings.Join(f.Path, ".") == strings.Join(path, ".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isSelected(s *types.StreamAndConfiguration) bool {
+	return s != nil && s.Config != nil && s.Config.Selected
+}
@@ -0,0 +1,208 @@
+package airbytesdk
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/evris99/airbyte-sdk/airbytetest"
+	"github.com/evris99/airbyte-sdk/types"
+)
+
+func testStream(name string, fields ...string) types.StreamAndConfiguration {
+	properties := make(map[string]interface{})
+	for _, field := range fields {
+		properties[field] = map[string]interface{}{"type": "string"}
+	}
+
+	return types.StreamAndConfiguration{
+		Stream: &types.StreamType{
+			Name:               name,
+			JsonSchema:         map[string]interface{}{"type": "object", "properties": properties},
+			SupportedSyncModes: []types.SupportedSyncModesEnum{types.FullRefresh, types.Incremental},
+		},
+		Config: &types.Config{SyncMode: types.FullRefresh, DestinationSyncMode: types.Append},
+	}
+}
+
+// Creates a connection with a hand-tuned users stream and returns it with the client
+func setupRefresh(t *testing.T) (*airbytetest.Server, *Client, *types.Connection) {
+	t.Helper()
+
+	srv := airbytetest.NewServer()
+	airbyte, err := New(srv.Endpoint())
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	conn := createTestConnection(t, airbyte)
+	users := testStream("users", "id", "email", "updated_at")
+	users.Config = &types.Config{
+		SyncMode:            types.Incremental,
+		CursorField:         []string{"updated_at"},
+		DestinationSyncMode: types.Append,
+		AliasName:           "customers",
+		Selected:            true,
+	}
+	conn.SyncCatalog = &types.SyncCatalogType{Streams: []types.StreamAndConfiguration{users}}
+
+	conn, err = airbyte.UpdateConnection(context.Background(), conn)
+	if err != nil {
+		t.Fatalf("could not update connection: %v", err)
+	}
+
+	return srv, airbyte, conn
+}
+
+func TestRefreshConnectionCatalogKeepsConfig(t *testing.T) {
+	srv, airbyte, conn := setupRefresh(t)
+	defer srv.Close()
+
+	srv.SetCatalog(*conn.SourceID, &types.SyncCatalogType{Streams: []types.StreamAndConfiguration{
+		testStream("users", "id", "email", "updated_at", "name"),
+		testStream("orders", "id"),
+	}})
+
+	refresh, err := airbyte.RefreshConnectionCatalog(context.Background(), conn.ConnectionId, CatalogPolicy{NewStreams: SelectNewStreams})
+	if err != nil {
+		t.Fatalf("could not refresh catalog: %v", err)
+	}
+
+	if len(refresh.Changes.AddedStreams) != 1 || len(refresh.Changes.UpdatedStreams) != 1 {
+		t.Fatalf("unexpected changes:\n%s", refresh.Changes)
+	}
+
+	users := refresh.Connection.SyncCatalog.Find("users", "")
+	if users == nil || !users.Config.Selected || users.Config.SyncMode != types.Incremental ||
+		users.Config.AliasName != "customers" || len(users.Config.CursorField) != 1 {
+		t.Fatalf("users stream config was not kept: %+v", users)
+	}
+
+	if _, ok := users.Stream.JsonSchema["properties"].(map[string]interface{})["name"]; !ok {
+		t.Fatal("users stream schema was not updated")
+	}
+
+	orders := refresh.Connection.SyncCatalog.Find("orders", "")
+	if orders == nil || !orders.Config.Selected {
+		t.Fatalf("orders stream should be selected: %+v", orders)
+	}
+}
+
+func TestRefreshConnectionCatalogIgnoreNewStreams(t *testing.T) {
+	srv, airbyte, conn := setupRefresh(t)
+	defer srv.Close()
+
+	srv.SetCatalog(*conn.SourceID, &types.SyncCatalogType{Streams: []types.StreamAndConfiguration{
+		testStream("users", "id", "email", "updated_at"),
+		testStream("orders", "id"),
+	}})
+
+	refresh, err := airbyte.RefreshConnectionCatalog(context.Background(), conn.ConnectionId, CatalogPolicy{})
+	if err != nil {
+		t.Fatalf("could not refresh catalog: %v", err)
+	}
+
+	orders := refresh.Connection.SyncCatalog.Find("orders", "")
+	if orders == nil || orders.Config.Selected {
+		t.Fatalf("orders stream should be added without being selected: %+v", orders)
+	}
+}
+
+func TestRefreshConnectionCatalogRemovedFields(t *testing.T) {
+	srv, airbyte, conn := setupRefresh(t)
+	defer srv.Close()
+
+	srv.SetCatalog(*conn.SourceID, &types.SyncCatalogType{Streams: []types.StreamAndConfiguration{
+		testStream("users", "id", "updated_at"),
+	}})
+
+	ctx := context.Background()
+	_, err := airbyte.RefreshConnectionCatalog(ctx, conn.ConnectionId, CatalogPolicy{})
+	if !errors.Is(err, ErrRemovedFields) {
+		t.Fatalf("expected removed fields error, got: %v", err)
+	}
+
+	unchanged, err := airbyte.GetConnection(ctx, conn.ConnectionId)
+	if err != nil {
+		t.Fatalf("could not get connection: %v", err)
+	}
+
+	if _, ok := unchanged.SyncCatalog.Find("users", "").Stream.JsonSchema["properties"].(map[string]interface{})["email"]; !ok {
+		t.Fatal("connection should not be updated")
+	}
+
+	refresh, err := airbyte.RefreshConnectionCatalog(ctx, conn.ConnectionId, CatalogPolicy{RemovedFields: AcceptRemovedFields})
+	if err != nil {
+		t.Fatalf("could not refresh catalog: %v", err)
+	}
+
+	if !refresh.Changes.Breaking() || refresh.Connection == nil {
+		t.Fatalf("expected the connection to be updated with breaking changes:\n%s", refresh.Changes)
+	}
+}
+
+func TestRefreshConnectionCatalogRemovedCursor(t *testing.T) {
+	srv, airbyte, conn := setupRefresh(t)
+	defer srv.Close()
+
+	srv.SetCatalog(*conn.SourceID, &types.SyncCatalogType{Streams: []types.StreamAndConfiguration{
+		testStream("users", "id", "email"),
+	}})
+
+	_, err := airbyte.RefreshConnectionCatalog(context.Background(), conn.ConnectionId, CatalogPolicy{RemovedFields: AcceptRemovedFields})
+	if !errors.Is(err, types.ErrUnknownField) {
+		t.Fatalf("expected unknown field error, got: %v", err)
+	}
+}
+
+func TestRefreshConnectionCatalogUnsupportedSyncMode(t *testing.T) {
+	srv, airbyte, conn := setupRefresh(t)
+	defer srv.Close()
+
+	users := testStream("users", "id", "email", "updated_at", "name")
+	users.Stream.SupportedSyncModes = []types.SupportedSyncModesEnum{types.FullRefresh}
+	srv.SetCatalog(*conn.SourceID, &types.SyncCatalogType{Streams: []types.StreamAndConfiguration{users}})
+
+	ctx := context.Background()
+	_, err := airbyte.RefreshConnectionCatalog(ctx, conn.ConnectionId, CatalogPolicy{RemovedFields: AcceptRemovedFields})
+	if !errors.Is(err, types.ErrUnsupportedSyncMode) {
+		t.Fatalf("expected unsupported sync mode error, got: %v", err)
+	}
+
+	// Unselected streams fall back to a mode they support
+	conn.SyncCatalog.Streams[0].Config.Selected = false
+	if _, err := airbyte.UpdateConnection(ctx, conn); err != nil {
+		t.Fatalf("could not update connection: %v", err)
+	}
+
+	refresh, err := airbyte.RefreshConnectionCatalog(ctx, conn.ConnectionId, CatalogPolicy{RemovedFields: AcceptRemovedFields})
+	if err != nil {
+		t.Fatalf("could not refresh catalog: %v", err)
+	}
+
+	if mode := refresh.Connection.SyncCatalog.Find("users", "").Config.SyncMode; mode != types.FullRefresh {
+		t.Fatalf("expected the unselected stream to fall back to full refresh, got %s", mode)
+	}
+}
+
+func TestRefreshConnectionCatalogFailedDiscovery(t *testing.T) {
+	srv, airbyte, conn := setupRefresh(t)
+	defer srv.Close()
+
+	srv.FailDiscovery(*conn.SourceID)
+
+	ctx := context.Background()
+	_, err := airbyte.RefreshConnectionCatalog(ctx, conn.ConnectionId, CatalogPolicy{RemovedFields: AcceptRemovedFields})
+	if !errors.Is(err, ErrDiscoveryFailed) {
+		t.Fatalf("expected discovery failed error, got: %v", err)
+	}
+
+	unchanged, err := airbyte.GetConnection(ctx, conn.ConnectionId)
+	if err != nil {
+		t.Fatalf("could not get connection: %v", err)
+	}
+
+	if unchanged.SyncCatalog.Find("users", "") == nil {
+		t.Fatal("connection should not be updated")
+	}
+}
@@ -0,0 +1,146 @@
+package airbytesdk
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultInitialBackoff = 500 * time.Millisecond
+	defaultMaxBackoff     = 30 * time.Second
+)
+
+// The operations that create a new resource or job each time they are called
+var nonIdempotentOperations = map[string]bool{
+	"create": true,
+	"clone":  true,
+	"sync":   true,
+	"reset":  true,
+}
+
+// A RetryPolicy controls how failed requests are retried.
+// Requests are retried on connection errors, 429 and 5XX responses
+type RetryPolicy struct {
+	// The maximum number of attempts of a request, including the first one.
+	// Values less than 2 disable retries
+	MaxAttempts int
+	// The delay before the first retry. It is doubled after every retry. Defaults to 500ms
+	InitialBackoff time.Duration
+	// The upper limit of the delay between retries, including delays requested with Retry-After. Defaults to 30s
+	MaxBackoff time.Duration
+	// Also retry requests that create resources or start jobs.
+	// A retried request may create a duplicate if the failed attempt reached the server
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns a policy that makes up to 4 attempts of requests that are safe to retry
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: defaultInitialBackoff,
+		MaxBackoff:     defaultMaxBackoff,
+	}
+}
+
+// WithRetryPolicy retries failed requests according to the given policy
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// Returns true if the request to the given URL may be retried
+func (p RetryPolicy) allows(u *url.URL) bool {
+	if p.MaxAttempts < 2 {
+		return false
+	}
+
+	return p.RetryNonIdempotent || !nonIdempotentOperations[path.Base(u.Path)]
+}
+
+// Returns the delay before the retry that follows the given attempt.
+// The Retry-After header of the response is respected if present, up to the maximum backoff
+func (p RetryPolicy) backoff(attempt int, res *http.Response) time.Duration {
+	maxBackoff := p.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultMaxBackoff
+	}
+
+	if res != nil {
+		if delay, ok := parseRetryAfter(res.Header.Get("Retry-After"), time.Now()); ok {
+			if delay > maxBackoff {
+				return maxBackoff
+			}
+
+			return delay
+		}
+	}
+
+	delay := p.InitialBackoff
+	if delay <= 0 {
+		delay = defaultInitialBackoff
+	}
+
+	for i := 1; i < attempt && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+
+	if delay > maxBackoff {
+		delay = maxBackoff
+	}
+
+	// Spread the retries of concurrent requests between half and the whole delay
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
+
+// An error of the HTTP client while sending a request or receiving its response
+type transportError struct {
+	err error
+}
+
+func (e *transportError) Error() string {
+	return "could not execute request: " + e.err.Error()
+}
+
+func (e *transportError) Unwrap() error {
+	return e.err
+}
+
+// Returns true if a request with the given result should be retried.
+// Only transport errors are retried, unless the request was canceled,
+// since errors that happen before sending a request fail the same way every time
+func shouldRetry(res *http.Response, err error) bool {
+	if err != nil {
+		var transportErr *transportError
+		return errors.As(err, &transportErr) && !errors.Is(err, context.Canceled)
+	}
+
+	return res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500
+}
+
+// Parses the value of a Retry-After header, given either in seconds or as a date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := date.Sub(now); delay > 0 {
+			return delay, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
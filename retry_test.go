@@ -0,0 +1,147 @@
+package airbytesdk
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/evris99/airbyte-sdk/types"
+)
+
+func TestRetry(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"name":"test"}` {
+			t.Errorf("unexpected body on attempt %d: %s", requests, body)
+		}
+
+		if requests < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Write([]byte(`{"name":"test"}`))
+	}))
+	defer srv.Close()
+
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	airbyte, err := New(srv.URL+"/api", WithRetryPolicy(policy))
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	if _, err := airbyte.SearchSource(context.Background(), &types.Source{Name: "test"}); err != nil {
+		t.Fatalf("could not search source: %v", err)
+	}
+
+	if requests != 3 {
+		t.Fatalf("expected 3 attempts, got %d", requests)
+	}
+
+	// Creating a source is not retried unless allowed by the policy
+	requests = 0
+	if _, err := airbyte.CreateSource(context.Background(), &types.Source{Name: "test"}); err == nil {
+		t.Fatal("expected create to fail")
+	}
+
+	if requests != 1 {
+		t.Fatalf("expected 1 attempt, got %d", requests)
+	}
+
+	requests = 0
+	policy.RetryNonIdempotent = true
+	airbyte, err = New(srv.URL+"/api", WithRetryPolicy(policy))
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	if _, err := airbyte.CreateSource(context.Background(), &types.Source{Name: "test"}); err != nil {
+		t.Fatalf("could not create source: %v", err)
+	}
+}
+
+type credentialsFunc func(ctx context.Context, req *http.Request) error
+
+func (f credentialsFunc) SetCredentials(ctx context.Context, req *http.Request) error {
+	return f(ctx, req)
+}
+
+func TestRetryTransportErrors(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	transportErr := errors.New("connection reset")
+	attempts := 0
+
+	tests := []struct {
+		name     string
+		err      error
+		option   Option
+		attempts int
+	}{
+		{"transport error", transportErr, nil, 3},
+		{"canceled", context.Canceled, nil, 1},
+		{"credentials error", transportErr, WithCredentialsProvider(credentialsFunc(func(ctx context.Context, req *http.Request) error {
+			attempts++
+			return errors.New("token expired")
+		})), 1},
+	}
+
+	for _, test := range tests {
+		attempts = 0
+		transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			attempts++
+			return nil, test.err
+		})
+
+		opts := []Option{WithRetryPolicy(policy), WithTransport(transport)}
+		if test.option != nil {
+			opts = append(opts, test.option)
+		}
+
+		airbyte, err := New("http://localhost:8000/api", opts...)
+		if err != nil {
+			t.Fatalf("could not create instance: %v", err)
+		}
+
+		if _, err := airbyte.ListWorkspaces(context.Background()); err == nil {
+			t.Errorf("%s: expected the request to fail", test.name)
+		}
+
+		if attempts != test.attempts {
+			t.Errorf("%s: expected %d attempts, got %d", test.name, test.attempts, attempts)
+		}
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	for attempt, max := range map[int]time.Duration{1: 100 * time.Millisecond, 3: 400 * time.Millisecond, 10: time.Second} {
+		delay := policy.backoff(attempt, nil)
+		if delay < max/2 || delay > max {
+			t.Errorf("attempt %d: delay %v is not between %v and %v", attempt, delay, max/2, max)
+		}
+	}
+
+	res := &http.Response{Header: http.Header{"Retry-After": []string{"7"}}}
+	if delay := (RetryPolicy{MaxBackoff: 10 * time.Second}).backoff(1, res); delay != 7*time.Second {
+		t.Fatalf("expected the Retry-After delay, got %v", delay)
+	}
+
+	// Retry-After cannot exceed the maximum backoff
+	res = &http.Response{Header: http.Header{"Retry-After": []string{"3600"}}}
+	if delay := policy.backoff(1, res); delay != time.Second {
+		t.Fatalf("expected the maximum backoff, got %v", delay)
+	}
+
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	if delay, ok := parseRetryAfter("Wed, 01 Jun 2022 12:00:05 GMT", now); !ok || delay != 5*time.Second {
+		t.Fatalf("could not parse date, got %v", delay)
+	}
+}
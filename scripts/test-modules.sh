@@ -0,0 +1,29 @@
+#!/bin/sh
+# Runs the tests of the SDK and of the nested otelairbyte and prommetrics modules.
+# The nested modules are tested against the SDK of this checkout through a temporary workspace,
+# which also replaces the SDK versions they require, so those do not have to be published yet
+set -e
+
+root=$(cd "$(dirname "$0")/.." && pwd)
+modules="otelairbyte prommetrics"
+work=$(mktemp -d)
+trap 'rm -rf "$work"' EXIT
+
+{
+	printf 'go 1.21\n\nuse (\n\t%s\n' "$root"
+	for module in $modules; do
+		printf '\t%s\n' "$root/$module"
+	done
+	printf ')\n\n'
+
+	for module in $modules; do
+		awk '$1 == "github.com/evris99/airbyte-sdk" { print $2 }' "$root/$module/go.mod"
+	done | sort -u | while read -r version; do
+		printf 'replace github.com/evris99/airbyte-sdk %s => %s\n' "$version" "$root"
+	done
+} > "$work/go.work"
+
+for module in . $modules; do
+	echo "testing $module"
+	(cd "$root/$module" && GOWORK="$work/go.work" GOFLAGS=-mod=readonly go test ./...)
+done
@@ -58,6 +58,14 @@ func (c *Client) ListWorkspaceSources(ctx context.Context, workspaceID *uuid.UUI
 	return types.SourcesFromJSON(res.Body)
 }
 
+// IterateWorkspaceSources returns an iterator over the sources of the workspace that decodes them one at a time
+func (c *Client) IterateWorkspaceSources(ctx context.Context, workspaceID *uuid.UUID) *SourceIterator {
+	data := make(map[string]*uuid.UUID)
+	data["workspaceId"] = workspaceID
+
+	return &SourceIterator{listIterator: c.newListIterator(ctx, "/v1/sources/list", data, "sources")}
+}
+
 // GetSource returns the source with the given ID
 func (c *Client) GetSource(ctx context.Context, id *uuid.UUID) (*types.Source, error) {
 	u, err := appendToURL(c.endpoint, "/v1/sources/get")
@@ -165,3 +173,24 @@ func (c *Client) CheckSourceConnectionUpdate(ctx context.Context, source *types.
 
 	return types.ConnectionCheckFromJSON(res.Body)
 }
+
+// DiscoverSourceSchema discovers the schema of the source with the given ID and returns its catalog.
+// If disableCache is true a new discovery job is always run instead of returning a cached catalog
+func (c *Client) DiscoverSourceSchema(ctx context.Context, id *uuid.UUID, disableCache bool) (*types.SourceDiscoverSchema, error) {
+	u, err := appendToURL(c.endpoint, "/v1/sources/discover_schema")
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]interface{})
+	data["sourceId"] = id
+	data["disable_cache"] = disableCache
+
+	res, err := c.makeRequest(ctx, u, data)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	return types.SourceDiscoverSchemaFromJSON(res.Body)
+}
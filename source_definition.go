@@ -60,6 +60,11 @@ func (c *Client) ListSourceDefinitions(ctx context.Context) ([]types.SourceDefin
 	return types.SourceDefinitionsFromJSON(res.Body)
 }
 
+// IterateSourceDefinitions returns an iterator over all source definitions that decodes them one at a time
+func (c *Client) IterateSourceDefinitions(ctx context.Context) *SourceDefinitionIterator {
+	return &SourceDefinitionIterator{listIterator: c.newListIterator(ctx, "/v1/source_definitions/list", nil, "sourceDefinitions")}
+}
+
 // ListLatestSourceDefinitions returns the latest source definitions the current Airbyte deployment is configured to use
 func (c *Client) ListLatestSourceDefinitions(ctx context.Context) ([]types.SourceDefinition, error) {
 	u, err := appendToURL(c.endpoint, "/v1/source_definitions/list_latest")
@@ -4,11 +4,15 @@ import (
 	"context"
 	"testing"
 
+	"github.com/evris99/airbyte-sdk/airbytetest"
 	"github.com/evris99/airbyte-sdk/types"
 )
 
 func TestSourceDefinitions(t *testing.T) {
-	airbyte, err := New("http://localhost:8000/api")
+	srv := airbytetest.NewServer()
+	defer srv.Close()
+
+	airbyte, err := New(srv.Endpoint())
 	if err != nil {
 		t.Fatalf("could not create instance: %v", err)
 	}
@@ -0,0 +1,86 @@
+package airbytesdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestDiscoverSourceSchema(t *testing.T) {
+	sourceID := uuid.New()
+	catalogID := uuid.New()
+	jobID := uuid.New()
+
+	tests := []struct {
+		name      string
+		response  string
+		succeeded bool
+		catalog   bool
+	}{
+		{
+			name:      "succeeded",
+			response:  `{"catalog":{"streams":[{"stream":{"name":"pokemon","supportedSyncModes":["full_refresh"]},"config":{"syncMode":"full_refresh","selected":true}}]},"jobInfo":{"id":"` + jobID.String() + `","configType":"discover_schema","succeeded":true},"catalogId":"` + catalogID.String() + `"}`,
+			succeeded: true,
+			catalog:   true,
+		},
+		{
+			name:     "failed",
+			response: `{"jobInfo":{"id":"` + jobID.String() + `","configType":"discover_schema","succeeded":false}}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v1/sources/discover_schema" {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+
+				var body map[string]interface{}
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Errorf("could not decode request: %v", err)
+				}
+
+				if body["sourceId"] != sourceID.String() || body["disable_cache"] != true {
+					t.Errorf("unexpected request %v", body)
+				}
+
+				w.Write([]byte(test.response))
+			}))
+			defer srv.Close()
+
+			airbyte, err := New(srv.URL + "/api")
+			if err != nil {
+				t.Fatalf("could not create instance: %v", err)
+			}
+
+			discovered, err := airbyte.DiscoverSourceSchema(context.Background(), &sourceID, true)
+			if err != nil {
+				t.Fatalf("could not discover schema: %v", err)
+			}
+
+			if discovered.JobInfo == nil || discovered.JobInfo.ID == nil || *discovered.JobInfo.ID != jobID || discovered.JobInfo.Succeeded != test.succeeded {
+				t.Fatalf("unexpected job info %+v", discovered.JobInfo)
+			}
+
+			if !test.catalog {
+				if discovered.Catalog != nil || discovered.CatalogId != nil {
+					t.Fatalf("expected no catalog, got %+v", discovered)
+				}
+				return
+			}
+
+			if discovered.CatalogId == nil || *discovered.CatalogId != catalogID {
+				t.Fatalf("unexpected catalog ID %v", discovered.CatalogId)
+			}
+
+			if stream := discovered.Catalog.Find("pokemon", ""); stream == nil || !stream.Config.Selected {
+				t.Fatalf("unexpected catalog %+v", discovered.Catalog)
+			}
+		})
+	}
+}
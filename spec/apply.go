@@ -0,0 +1,268 @@
+package spec
+
+import (
+	"context"
+	"fmt"
+
+	airbytesdk "github.com/evris99/airbyte-sdk"
+	"github.com/evris99/airbyte-sdk/types"
+	"github.com/google/uuid"
+)
+
+// Apply executes the changes of the plan in order and stops at the first failed change.
+// Changes applied before the failure are kept, so planning again continues where the apply stopped
+func (p *Plan) Apply(ctx context.Context, client *airbytesdk.Client) error {
+	for _, change := range p.Changes {
+		if err := p.apply(ctx, client, change); err != nil {
+			return fmt.Errorf("could not %s %s %q: %w", change.Action, change.Kind, change.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *Plan) apply(ctx context.Context, client *airbytesdk.Client, change Change) error {
+	switch change.Action {
+	case Create, Update:
+		switch change.Kind {
+		case WorkspaceKind:
+			workspace, err := client.CreateWorkspace(ctx, &types.Workspace{Name: change.Name})
+			if err != nil {
+				return err
+			}
+
+			p.workspaceID = workspace.WorkspaceId
+			return nil
+		case SourceKind:
+			return p.applySource(ctx, client, change)
+		case DestinationKind:
+			return p.applyDestination(ctx, client, change)
+		case OperationKind:
+			return p.applyOperation(ctx, client, change)
+		case ConnectionKind:
+			return p.applyConnection(ctx, client, change)
+		}
+	case Delete:
+		switch change.Kind {
+		case SourceKind:
+			return client.DeleteSource(ctx, change.ID)
+		case DestinationKind:
+			return client.DeleteDestination(ctx, change.ID)
+		case OperationKind:
+			return client.DeleteOperation(ctx, change.ID)
+		case ConnectionKind:
+			return client.DeleteConnection(ctx, change.ID)
+		}
+	}
+
+	return fmt.Errorf("unsupported change %s of %s", change.Action, change.Kind)
+}
+
+func (p *Plan) applySource(ctx context.Context, client *airbytesdk.Client, change Change) error {
+	var desired SourceSpec
+	for _, source := range p.spec.Sources {
+		if source.Name == change.Name {
+			desired = source
+		}
+	}
+
+	source := &types.Source{
+		SourceId:                change.ID,
+		Name:                    desired.Name,
+		ConnectionConfiguration: configuration(desired.Configuration),
+	}
+
+	var err error
+	if change.Action == Create {
+		source.WorkspaceId = p.workspaceID
+		source.SourceDefinitionId = p.sourceDefinitions[desired.Definition]
+		source, err = client.CreateSource(ctx, source)
+	} else {
+		source, err = client.UpdateSource(ctx, source)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	p.sources[source.Name] = source
+	return nil
+}
+
+func (p *Plan) applyDestination(ctx context.Context, client *airbytesdk.Client, change Change) error {
+	var desired DestinationSpec
+	for _, destination := range p.spec.Destinations {
+		if destination.Name == change.Name {
+			desired = destination
+		}
+	}
+
+	destination := &types.Destination{
+		DestinationId:           change.ID,
+		Name:                    desired.Name,
+		ConnectionConfiguration: configuration(desired.Configuration),
+	}
+
+	var err error
+	if change.Action == Create {
+		destination.WorkspaceId = p.workspaceID
+		destination.DestinationDefinitionId = p.destinationDefinitions[desired.Definition]
+		destination, err = client.CreateDestination(ctx, destination)
+	} else {
+		destination, err = client.UpdateDestination(ctx, destination)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	p.destinations[destination.Name] = destination
+	return nil
+}
+
+func (p *Plan) applyOperation(ctx context.Context, client *airbytesdk.Client, change Change) error {
+	var desired OperationSpec
+	for _, operation := range p.spec.Operations {
+		if operation.Name == change.Name {
+			desired = operation
+		}
+	}
+
+	operation := &types.Operation{
+		OperationId:           change.ID,
+		Name:                  desired.Name,
+		OperatorConfiguration: desired.OperatorConfiguration,
+	}
+
+	var err error
+	if change.Action == Create {
+		operation.WorkspaceId = p.workspaceID
+		operation, err = client.CreateOperation(ctx, operation)
+	} else {
+		operation, err = client.UpdateOperation(ctx, operation)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	p.operations[operation.Name] = operation
+	return nil
+}
+
+func (p *Plan) applyConnection(ctx context.Context, client *airbytesdk.Client, change Change) error {
+	var desired ConnectionSpec
+	for _, conn := range p.spec.Connections {
+		if conn.Name == change.Name {
+			desired = conn
+		}
+	}
+
+	source := p.sources[desired.Source]
+	destination := p.destinations[desired.Destination]
+	if source == nil || destination == nil {
+		return fmt.Errorf("source %q or destination %q does not exist", desired.Source, desired.Destination)
+	}
+
+	// Updates start from the live connection, so the fields the spec does not manage are kept
+	live := p.connections[desired.Name]
+	conn := &types.Connection{SourceID: source.SourceId, DestinationId: destination.DestinationId}
+	if change.Action == Update && live != nil {
+		updated := *live
+		conn = &updated
+	}
+
+	conn.Name = desired.Name
+	conn.Status = connectionStatus(&desired)
+	conn.Schedule = desired.Schedule
+	conn.Prefix = desired.Prefix
+	conn.OperationIds = make([]uuid.UUID, 0, len(desired.Operations))
+	if desired.NamespaceDefinition != "" {
+		conn.NamespaceDefinition = desired.NamespaceDefinition
+	}
+	if desired.NamespaceFormat != "" {
+		conn.NamespaceFormat = desired.NamespaceFormat
+	}
+
+	for _, name := range desired.Operations {
+		operation := p.operations[name]
+		if operation == nil || operation.OperationId == nil {
+			return fmt.Errorf("operation %q does not exist", name)
+		}
+		conn.OperationIds = append(conn.OperationIds, *operation.OperationId)
+	}
+
+	var catalog *types.SyncCatalogType
+	if change.Action == Update && live != nil {
+		catalog = live.SyncCatalog
+	} else {
+		discovered, err := client.DiscoverSourceSchema(ctx, source.SourceId, false)
+		if err != nil {
+			return fmt.Errorf("could not discover source schema: %w", err)
+		}
+		catalog = discovered.Catalog
+	}
+
+	if desired.Streams != nil {
+		var err error
+		if catalog, err = buildCatalog(catalog, desired.Streams); err != nil {
+			return err
+		}
+	}
+	conn.SyncCatalog = catalog
+
+	var err error
+	if change.Action == Create {
+		conn, err = client.CreateConnection(ctx, conn)
+	} else {
+		conn.ConnectionId = change.ID
+		conn, err = client.UpdateConnection(ctx, conn)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	p.connections[conn.Name] = conn
+	return nil
+}
+
+// Selects and configures the streams of the spec and deselects all the others
+func buildCatalog(catalog *types.SyncCatalogType, streams []StreamSpec) (*types.SyncCatalogType, error) {
+	if catalog == nil {
+		catalog = new(types.SyncCatalogType)
+	}
+
+	b := types.NewCatalogBuilder(catalog)
+	for _, s := range catalog.Streams {
+		if s.Stream != nil {
+			b.Stream(s.Stream.Name, s.Stream.Namespace).Deselect()
+		}
+	}
+
+	for _, stream := range streams {
+		sb := b.Stream(stream.Name, stream.Namespace).
+			Select().
+			SyncMode(stream.SyncMode, stream.DestinationSyncMode).
+			Alias(aliasOf(stream.Alias, stream.Name))
+
+		if stream.CursorField != nil {
+			sb.CursorField(stream.CursorField...)
+		}
+
+		if stream.PrimaryKey != nil {
+			sb.PrimaryKey(stream.PrimaryKey...)
+		}
+	}
+
+	return b.Build()
+}
+
+// Returns the configuration to send for a connector, which must not be null
+func configuration(config map[string]interface{}) map[string]interface{} {
+	if config == nil {
+		return make(map[string]interface{})
+	}
+
+	return config
+}
@@ -0,0 +1,583 @@
+package spec
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	airbytesdk "github.com/evris99/airbyte-sdk"
+	"github.com/evris99/airbyte-sdk/types"
+	"github.com/google/uuid"
+)
+
+// The value airbyte returns in place of secrets
+const maskedSecret = "**********"
+
+// The action a change performs on a resource
+type Action string
+
+const (
+	Create Action = "create"
+	Update Action = "update"
+	Delete Action = "delete"
+)
+
+// The kind of a resource
+type Kind string
+
+const (
+	WorkspaceKind   Kind = "workspace"
+	SourceKind      Kind = "source"
+	DestinationKind Kind = "destination"
+	OperationKind   Kind = "operation"
+	ConnectionKind  Kind = "connection"
+)
+
+// A change of a single resource
+type Change struct {
+	Action Action
+	Kind   Kind
+	Name   string
+	// The ID of the live resource. Nil for creates
+	ID *uuid.UUID
+	// The names of the fields that differ, for updates
+	Fields []string
+}
+
+func (c Change) String() string {
+	symbol := map[Action]string{Create: "+", Update: "~", Delete: "-"}[c.Action]
+	s := fmt.Sprintf("%s %s %q", symbol, c.Kind, c.Name)
+	if len(c.Fields) > 0 {
+		s += " (" + strings.Join(c.Fields, ", ") + ")"
+	}
+
+	return s
+}
+
+// A Plan is the list of changes that reconcile a workspace with a spec
+type Plan struct {
+	// The changes in the order they are applied
+	Changes []Change
+
+	spec                   *Spec
+	workspaceID            *uuid.UUID
+	sourceDefinitions      map[string]*uuid.UUID
+	destinationDefinitions map[string]*uuid.UUID
+	sources                map[string]*types.Source
+	destinations           map[string]*types.Destination
+	operations             map[string]*types.Operation
+	connections            map[string]*types.Connection
+}
+
+// Empty returns true if the workspace already matches the spec
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// String renders the changes one per line
+func (p *Plan) String() string {
+	var b strings.Builder
+	for _, c := range p.Changes {
+		b.WriteString(c.String())
+		b.WriteByte('\n')
+	}
+
+	return b.String()
+}
+
+// Plan compares the spec with the live workspace and returns the changes needed to reconcile them.
+// Live resources are matched by name and the ones missing from the spec are deleted.
+// Operations are found through the connections they are attached to and are only created for connections that use them
+func (s *Spec) Plan(ctx context.Context, client *airbytesdk.Client) (*Plan, error) {
+	p := &Plan{
+		spec:                   s,
+		sourceDefinitions:      make(map[string]*uuid.UUID),
+		destinationDefinitions: make(map[string]*uuid.UUID),
+		sources:                make(map[string]*types.Source),
+		destinations:           make(map[string]*types.Destination),
+		operations:             make(map[string]*types.Operation),
+		connections:            make(map[string]*types.Connection),
+	}
+
+	if err := p.resolveDefinitions(ctx, client); err != nil {
+		return nil, err
+	}
+
+	if err := p.loadWorkspace(ctx, client); err != nil {
+		return nil, err
+	}
+
+	if p.workspaceID == nil {
+		p.Changes = append(p.Changes, Change{Action: Create, Kind: WorkspaceKind, Name: s.Workspace})
+	}
+
+	for _, plan := range []func() error{p.planSources, p.planDestinations, p.planOperations, p.planConnections} {
+		if err := plan(); err != nil {
+			return nil, err
+		}
+	}
+
+	p.planDeletes()
+	return p, nil
+}
+
+// Maps the definitions of the spec, given by name or ID, to their IDs
+func (p *Plan) resolveDefinitions(ctx context.Context, client *airbytesdk.Client) error {
+	if len(p.spec.Sources) > 0 {
+		definitions, err := client.ListSourceDefinitions(ctx)
+		if err != nil {
+			return fmt.Errorf("could not list source definitions: %w", err)
+		}
+
+		for _, source := range p.spec.Sources {
+			for i := range definitions {
+				if matchesDefinition(source.Definition, definitions[i].Name, definitions[i].SourceDefinitionId) {
+					p.sourceDefinitions[source.Definition] = definitions[i].SourceDefinitionId
+				}
+			}
+
+			if p.sourceDefinitions[source.Definition] == nil {
+				return fmt.Errorf("%w: source %q: unknown definition %q", ErrInvalidSpec, source.Name, source.Definition)
+			}
+		}
+	}
+
+	if len(p.spec.Destinations) > 0 {
+		definitions, err := client.ListDestinationDefinitions(ctx)
+		if err != nil {
+			return fmt.Errorf("could not list destination definitions: %w", err)
+		}
+
+		for _, destination := range p.spec.Destinations {
+			for i := range definitions {
+				if matchesDefinition(destination.Definition, definitions[i].Name, definitions[i].DestinationDefinitionId) {
+					p.destinationDefinitions[destination.Definition] = definitions[i].DestinationDefinitionId
+				}
+			}
+
+			if p.destinationDefinitions[destination.Definition] == nil {
+				return fmt.Errorf("%w: destination %q: unknown definition %q", ErrInvalidSpec, destination.Name, destination.Definition)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Finds the workspace of the spec and loads its resources, if it exists
+func (p *Plan) loadWorkspace(ctx context.Context, client *airbytesdk.Client) error {
+	workspaces, err := client.ListWorkspaces(ctx)
+	if err != nil {
+		return fmt.Errorf("could not list workspaces: %w", err)
+	}
+
+	for i := range workspaces {
+		if workspaces[i].Name != p.spec.Workspace {
+			continue
+		}
+
+		if p.workspaceID != nil {
+			return fmt.Errorf("%w: workspace %q", ErrAmbiguousName, p.spec.Workspace)
+		}
+		p.workspaceID = workspaces[i].WorkspaceId
+	}
+
+	if p.workspaceID == nil {
+		return nil
+	}
+
+	sources, err := client.ListWorkspaceSources(ctx, p.workspaceID)
+	if err != nil {
+		return fmt.Errorf("could not list sources: %w", err)
+	}
+
+	for i := range sources {
+		if _, ok := p.sources[sources[i].Name]; ok {
+			return ambiguous(SourceKind, sources[i].Name)
+		}
+		p.sources[sources[i].Name] = &sources[i]
+	}
+
+	destinations, err := client.ListWorkspaceDestinations(ctx, p.workspaceID)
+	if err != nil {
+		return fmt.Errorf("could not list destinations: %w", err)
+	}
+
+	for i := range destinations {
+		if _, ok := p.destinations[destinations[i].Name]; ok {
+			return ambiguous(DestinationKind, destinations[i].Name)
+		}
+		p.destinations[destinations[i].Name] = &destinations[i]
+	}
+
+	connections, err := client.ListWorkspaceConnections(ctx, p.workspaceID)
+	if err != nil {
+		return fmt.Errorf("could not list connections: %w", err)
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	for i := range connections {
+		if _, ok := p.connections[connections[i].Name]; ok {
+			return ambiguous(ConnectionKind, connections[i].Name)
+		}
+		p.connections[connections[i].Name] = &connections[i]
+
+		operations, err := client.ListOperations(ctx, connections[i].ConnectionId)
+		if err != nil {
+			return fmt.Errorf("could not list operations: %w", err)
+		}
+
+		for j := range operations {
+			if operations[j].OperationId == nil || seen[*operations[j].OperationId] {
+				continue
+			}
+			seen[*operations[j].OperationId] = true
+
+			if _, ok := p.operations[operations[j].Name]; ok {
+				return ambiguous(OperationKind, operations[j].Name)
+			}
+			p.operations[operations[j].Name] = &operations[j]
+		}
+	}
+
+	return nil
+}
+
+func (p *Plan) planSources() error {
+	for _, source := range p.spec.Sources {
+		live, ok := p.sources[source.Name]
+		if !ok {
+			p.Changes = append(p.Changes, Change{Action: Create, Kind: SourceKind, Name: source.Name})
+			continue
+		}
+
+		if !sameID(live.SourceDefinitionId, p.sourceDefinitions[source.Definition]) {
+			return fmt.Errorf("%w: source %q: the definition cannot be changed", ErrReplaceRequired, source.Name)
+		}
+
+		if !configEqual(normalize(source.Configuration), normalize(live.ConnectionConfiguration)) {
+			p.Changes = append(p.Changes, Change{Action: Update, Kind: SourceKind, Name: source.Name, ID: live.SourceId, Fields: []string{"configuration"}})
+		}
+	}
+
+	return nil
+}
+
+func (p *Plan) planDestinations() error {
+	for _, destination := range p.spec.Destinations {
+		live, ok := p.destinations[destination.Name]
+		if !ok {
+			p.Changes = append(p.Changes, Change{Action: Create, Kind: DestinationKind, Name: destination.Name})
+			continue
+		}
+
+		if !sameID(live.DestinationDefinitionId, p.destinationDefinitions[destination.Definition]) {
+			return fmt.Errorf("%w: destination %q: the definition cannot be changed", ErrReplaceRequired, destination.Name)
+		}
+
+		if !configEqual(normalize(destination.Configuration), normalize(live.ConnectionConfiguration)) {
+			p.Changes = append(p.Changes, Change{Action: Update, Kind: DestinationKind, Name: destination.Name, ID: live.DestinationId, Fields: []string{"configuration"}})
+		}
+	}
+
+	return nil
+}
+
+// Plans the operations that connections of the spec refer to. Airbyte can only list operations
+// through their connections, so operations no connection refers to are not managed
+func (p *Plan) planOperations() error {
+	used := make(map[string]bool)
+	for _, conn := range p.spec.Connections {
+		for _, name := range conn.Operations {
+			used[name] = true
+		}
+	}
+
+	for _, operation := range p.spec.Operations {
+		live, ok := p.operations[operation.Name]
+		if !ok && used[operation.Name] {
+			p.Changes = append(p.Changes, Change{Action: Create, Kind: OperationKind, Name: operation.Name})
+			continue
+		}
+
+		if ok && !reflect.DeepEqual(normalize(operation.OperatorConfiguration), normalize(live.OperatorConfiguration)) {
+			p.Changes = append(p.Changes, Change{Action: Update, Kind: OperationKind, Name: operation.Name, ID: live.OperationId, Fields: []string{"operatorConfiguration"}})
+		}
+	}
+
+	return nil
+}
+
+func (p *Plan) planConnections() error {
+	for i := range p.spec.Connections {
+		conn := &p.spec.Connections[i]
+		live, ok := p.connections[conn.Name]
+		if !ok {
+			p.Changes = append(p.Changes, Change{Action: Create, Kind: ConnectionKind, Name: conn.Name})
+			continue
+		}
+
+		if source := p.sources[conn.Source]; source == nil || !sameID(live.SourceID, source.SourceId) {
+			return fmt.Errorf("%w: connection %q: the source cannot be changed", ErrReplaceRequired, conn.Name)
+		}
+
+		if destination := p.destinations[conn.Destination]; destination == nil || !sameID(live.DestinationId, destination.DestinationId) {
+			return fmt.Errorf("%w: connection %q: the destination cannot be changed", ErrReplaceRequired, conn.Name)
+		}
+
+		if fields := p.connectionFields(conn, live); len(fields) > 0 {
+			p.Changes = append(p.Changes, Change{Action: Update, Kind: ConnectionKind, Name: conn.Name, ID: live.ConnectionId, Fields: fields})
+		}
+	}
+
+	return nil
+}
+
+// Returns the names of the fields of the live connection that differ from the spec
+func (p *Plan) connectionFields(conn *ConnectionSpec, live *types.Connection) []string {
+	var fields []string
+	if connectionStatus(conn) != live.Status {
+		fields = append(fields, "status")
+	}
+
+	if !reflect.DeepEqual(conn.Schedule, live.Schedule) {
+		fields = append(fields, "schedule")
+	}
+
+	if conn.NamespaceDefinition != "" && conn.NamespaceDefinition != live.NamespaceDefinition {
+		fields = append(fields, "namespaceDefinition")
+	}
+
+	if conn.NamespaceFormat != "" && conn.NamespaceFormat != live.NamespaceFormat {
+		fields = append(fields, "namespaceFormat")
+	}
+
+	if conn.Prefix != live.Prefix {
+		fields = append(fields, "prefix")
+	}
+
+	var liveOperations []string
+	for _, id := range live.OperationIds {
+		name := id.String()
+		for _, operation := range p.operations {
+			if sameID(operation.OperationId, &id) {
+				name = operation.Name
+			}
+		}
+		liveOperations = append(liveOperations, name)
+	}
+
+	if !sameNames(conn.Operations, liveOperations) {
+		fields = append(fields, "operations")
+	}
+
+	if conn.Streams != nil && !streamsMatch(conn.Streams, live.SyncCatalog) {
+		fields = append(fields, "streams")
+	}
+
+	return fields
+}
+
+// Adds the deletes of the live resources missing from the spec, dependents first
+func (p *Plan) planDeletes() {
+	names := make(map[Kind]map[string]bool)
+	for _, kind := range []Kind{SourceKind, DestinationKind, OperationKind, ConnectionKind} {
+		names[kind] = make(map[string]bool)
+	}
+
+	for _, source := range p.spec.Sources {
+		names[SourceKind][source.Name] = true
+	}
+
+	for _, destination := range p.spec.Destinations {
+		names[DestinationKind][destination.Name] = true
+	}
+
+	for _, operation := range p.spec.Operations {
+		names[OperationKind][operation.Name] = true
+	}
+
+	for _, conn := range p.spec.Connections {
+		names[ConnectionKind][conn.Name] = true
+	}
+
+	ids := map[Kind]map[string]*uuid.UUID{
+		ConnectionKind:  make(map[string]*uuid.UUID),
+		OperationKind:   make(map[string]*uuid.UUID),
+		DestinationKind: make(map[string]*uuid.UUID),
+		SourceKind:      make(map[string]*uuid.UUID),
+	}
+
+	for name, conn := range p.connections {
+		ids[ConnectionKind][name] = conn.ConnectionId
+	}
+
+	for name, operation := range p.operations {
+		ids[OperationKind][name] = operation.OperationId
+	}
+
+	for name, destination := range p.destinations {
+		ids[DestinationKind][name] = destination.DestinationId
+	}
+
+	for name, source := range p.sources {
+		ids[SourceKind][name] = source.SourceId
+	}
+
+	for _, kind := range []Kind{ConnectionKind, OperationKind, DestinationKind, SourceKind} {
+		var deleted []string
+		for name := range ids[kind] {
+			if !names[kind][name] {
+				deleted = append(deleted, name)
+			}
+		}
+		sort.Strings(deleted)
+
+		for _, name := range deleted {
+			p.Changes = append(p.Changes, Change{Action: Delete, Kind: kind, Name: name, ID: ids[kind][name]})
+		}
+	}
+}
+
+// Returns true if the selected streams of the catalog are configured as in the spec
+func streamsMatch(streams []StreamSpec, catalog *types.SyncCatalogType) bool {
+	if catalog == nil {
+		catalog = new(types.SyncCatalogType)
+	}
+
+	selected := 0
+	for _, s := range catalog.Streams {
+		if s.Config != nil && s.Config.Selected {
+			selected++
+		}
+	}
+
+	if selected != len(streams) {
+		return false
+	}
+
+	for _, stream := range streams {
+		live := catalog.Find(stream.Name, stream.Namespace)
+		if live == nil || live.Config == nil || !live.Config.Selected {
+			return false
+		}
+
+		config := live.Config
+		if config.SyncMode != stream.SyncMode || config.DestinationSyncMode != stream.DestinationSyncMode || aliasOf(config.AliasName, stream.Name) != aliasOf(stream.Alias, stream.Name) {
+			return false
+		}
+
+		if stream.CursorField != nil && !reflect.DeepEqual(stream.CursorField, config.CursorField) {
+			return false
+		}
+
+		if stream.PrimaryKey != nil && !reflect.DeepEqual(stream.PrimaryKey, config.PrimaryKey) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns the alias of a stream, which defaults to its name
+func aliasOf(alias, name string) string {
+	if alias == "" {
+		return name
+	}
+
+	return alias
+}
+
+func connectionStatus(conn *ConnectionSpec) types.ConnectionStatus {
+	if conn.Status == "" {
+		return types.Active
+	}
+
+	return conn.Status
+}
+
+func matchesDefinition(definition, name string, id *uuid.UUID) bool {
+	if definition == name {
+		return true
+	}
+
+	parsed, err := uuid.Parse(definition)
+	return err == nil && sameID(&parsed, id)
+}
+
+func ambiguous(kind Kind, name string) error {
+	return fmt.Errorf("%w: %s %q", ErrAmbiguousName, kind, name)
+}
+
+func sameID(a, b *uuid.UUID) bool {
+	return a != nil && b != nil && *a == *b
+}
+
+func sameNames(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	a = append([]string(nil), a...)
+	b = append([]string(nil), b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	return reflect.DeepEqual(a, b)
+}
+
+// Converts a value to its generic JSON form, so values decoded from different documents can be compared
+func normalize(v interface{}) interface{} {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+
+	var normalized interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return v
+	}
+
+	return normalized
+}
+
+// Compares the desired configuration with the live one. Secrets masked by airbyte match any value
+func configEqual(desired, live interface{}) bool {
+	if s, ok := live.(string); ok && s == maskedSecret {
+		return true
+	}
+
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok || len(l) != len(d) {
+			return len(d) == 0 && live == nil
+		}
+
+		for key, value := range d {
+			liveValue, ok := l[key]
+			if !ok || !configEqual(value, liveValue) {
+				return false
+			}
+		}
+
+		return true
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok || len(l) != len(d) {
+			return false
+		}
+
+		for i := range d {
+			if !configEqual(d[i], l[i]) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return reflect.DeepEqual(desired, live)
+}
@@ -0,0 +1,214 @@
+package spec
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	airbytesdk "github.com/evris99/airbyte-sdk"
+	"github.com/evris99/airbyte-sdk/airbytetest"
+	"github.com/evris99/airbyte-sdk/types"
+)
+
+func newTestClient(t *testing.T) (*airbytetest.Server, *airbytesdk.Client) {
+	t.Helper()
+
+	srv := airbytetest.NewServer()
+	client, err := airbytesdk.New(srv.Endpoint())
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	return srv, client
+}
+
+func planAndApply(t *testing.T, client *airbytesdk.Client, s *Spec) *Plan {
+	t.Helper()
+
+	plan, err := s.Plan(context.Background(), client)
+	if err != nil {
+		t.Fatalf("could not plan: %v", err)
+	}
+
+	if err := plan.Apply(context.Background(), client); err != nil {
+		t.Fatalf("could not apply plan:\n%s\n%v", plan, err)
+	}
+
+	return plan
+}
+
+func TestPlanAndApply(t *testing.T) {
+	srv, client := newTestClient(t)
+	defer srv.Close()
+	ctx := context.Background()
+
+	s, err := ParseFile("testdata/workspace.yaml")
+	if err != nil {
+		t.Fatalf("could not parse spec: %v", err)
+	}
+
+	// Create the connectors first, so the fake server can discover the catalog of the source.
+	// The operation is not created until a connection uses it
+	connections := s.Connections
+	s.Connections = nil
+	plan := planAndApply(t, client, s)
+
+	expected := "+ workspace \"analytics\"\n+ source \"pokeapi\"\n+ destination \"local\"\n"
+	if plan.String() != expected {
+		t.Fatalf("unexpected plan:\n%s", plan)
+	}
+
+	source := plan.sources["pokeapi"]
+	srv.SetCatalog(*source.SourceId, &types.SyncCatalogType{Streams: []types.StreamAndConfiguration{
+		{Stream: &types.StreamType{Name: "pokemon", JsonSchema: map[string]interface{}{}}, Config: &types.Config{Selected: true}},
+		{Stream: &types.StreamType{Name: "berries", JsonSchema: map[string]interface{}{}}, Config: &types.Config{Selected: true}},
+	}})
+
+	s.Connections = connections
+	plan = planAndApply(t, client, s)
+	if plan.String() != "+ operation \"normalize\"\n+ connection \"pokeapi-to-local\"\n" {
+		t.Fatalf("unexpected plan:\n%s", plan)
+	}
+
+	conn := plan.connections["pokeapi-to-local"]
+	if len(conn.OperationIds) != 1 || conn.Schedule.Units != 24 {
+		t.Fatalf("unexpected connection: %+v", conn)
+	}
+
+	if !conn.SyncCatalog.Find("pokemon", "").Config.Selected || conn.SyncCatalog.Find("berries", "").Config.Selected {
+		t.Fatalf("only the pokemon stream should be selected: %+v", conn.SyncCatalog)
+	}
+
+	plan, err = s.Plan(ctx, client)
+	if err != nil {
+		t.Fatalf("could not plan: %v", err)
+	}
+
+	if !plan.Empty() {
+		t.Fatalf("expected an empty plan after apply, got:\n%s", plan)
+	}
+
+	// Change the workspace and remove the operation
+	s.Sources[0].Configuration["pokemon_name"] = "pikachu"
+	s.Connections[0].Status = types.Inactive
+	s.Connections[0].Operations = nil
+	s.Connections[0].Streams = append(s.Connections[0].Streams, StreamSpec{
+		Name:                "berries",
+		SyncMode:            types.FullRefresh,
+		DestinationSyncMode: types.Append,
+	})
+	s.Operations = nil
+
+	plan = planAndApply(t, client, s)
+	expected = "~ source \"pokeapi\" (configuration)\n" +
+		"~ connection \"pokeapi-to-local\" (status, operations, streams)\n" +
+		"- operation \"normalize\"\n"
+	if plan.String() != expected {
+		t.Fatalf("unexpected plan:\n%s", plan)
+	}
+
+	updated, err := client.GetConnection(ctx, conn.ConnectionId)
+	if err != nil {
+		t.Fatalf("could not get connection: %v", err)
+	}
+
+	if updated.Status != types.Inactive || !updated.SyncCatalog.Find("berries", "").Config.Selected {
+		t.Fatalf("connection was not updated: %+v", updated)
+	}
+
+	// Remove everything but the workspace
+	plan = planAndApply(t, client, &Spec{Workspace: "analytics"})
+	expected = "- connection \"pokeapi-to-local\"\n- destination \"local\"\n- source \"pokeapi\"\n"
+	if plan.String() != expected {
+		t.Fatalf("unexpected plan:\n%s", plan)
+	}
+}
+
+func TestApplyKeepsUnmanagedConnectionFields(t *testing.T) {
+	srv, client := newTestClient(t)
+	defer srv.Close()
+	ctx := context.Background()
+
+	s, err := ParseFile("testdata/workspace.yaml")
+	if err != nil {
+		t.Fatalf("could not parse spec: %v", err)
+	}
+
+	connections := s.Connections
+	s.Connections = nil
+	plan := planAndApply(t, client, s)
+	srv.SetCatalog(*plan.sources["pokeapi"].SourceId, &types.SyncCatalogType{Streams: []types.StreamAndConfiguration{
+		{Stream: &types.StreamType{Name: "pokemon", JsonSchema: map[string]interface{}{}}, Config: &types.Config{Selected: true}},
+	}})
+
+	s.Connections = connections
+	plan = planAndApply(t, client, s)
+
+	// Set fields the spec does not manage outside of the spec
+	conn, err := client.GetConnection(ctx, plan.connections["pokeapi-to-local"].ConnectionId)
+	if err != nil {
+		t.Fatalf("could not get connection: %v", err)
+	}
+
+	conn.ResourceRequirements = &types.ResourceRequirements{CpuLimit: "2", MemoryLimit: "4Gi"}
+	conn.NamespaceDefinition = "customformat"
+	conn.NamespaceFormat = "raw_${SOURCE_NAMESPACE}"
+	if _, err := client.UpdateConnection(ctx, conn); err != nil {
+		t.Fatalf("could not update connection: %v", err)
+	}
+
+	s.Connections[0].Status = types.Inactive
+	plan = planAndApply(t, client, s)
+	if plan.String() != "~ connection \"pokeapi-to-local\" (status)\n" {
+		t.Fatalf("unexpected plan:\n%s", plan)
+	}
+
+	updated, err := client.GetConnection(ctx, conn.ConnectionId)
+	if err != nil {
+		t.Fatalf("could not get connection: %v", err)
+	}
+
+	if updated.Status != types.Inactive {
+		t.Errorf("connection was not updated: %+v", updated)
+	}
+
+	if updated.ResourceRequirements == nil || updated.ResourceRequirements.CpuLimit != "2" {
+		t.Errorf("the resource requirements were not kept: %+v", updated.ResourceRequirements)
+	}
+
+	if updated.NamespaceDefinition != "customformat" || updated.NamespaceFormat != "raw_${SOURCE_NAMESPACE}" {
+		t.Errorf("the namespace was not kept: %q %q", updated.NamespaceDefinition, updated.NamespaceFormat)
+	}
+}
+
+func TestPlanMaskedSecrets(t *testing.T) {
+	if !configEqual(
+		normalize(map[string]interface{}{"password": "hunter2", "port": 5432}),
+		normalize(map[string]interface{}{"password": maskedSecret, "port": 5432}),
+	) {
+		t.Fatal("masked secrets should match any value")
+	}
+
+	if configEqual(
+		normalize(map[string]interface{}{"password": "hunter2", "port": 5432}),
+		normalize(map[string]interface{}{"password": maskedSecret, "port": 5433}),
+	) {
+		t.Fatal("different values should not match")
+	}
+}
+
+func TestPlanDefinitionChange(t *testing.T) {
+	srv, client := newTestClient(t)
+	defer srv.Close()
+
+	s := &Spec{Workspace: "analytics", Sources: []SourceSpec{{Name: "pokeapi", Definition: "PokeAPI"}}}
+	planAndApply(t, client, s)
+
+	other := srv.AddSourceDefinition(&types.SourceDefinition{Definition: types.Definition{Name: "Other"}})
+	s.Sources[0].Definition = other.String()
+
+	_, err := s.Plan(context.Background(), client)
+	if err == nil || !strings.Contains(err.Error(), "definition cannot be changed") {
+		t.Fatalf("expected replace error, got: %v", err)
+	}
+}
@@ -0,0 +1,227 @@
+// Package spec manages the resources of an airbyte workspace declaratively.
+//
+// A Spec describes the sources, destinations, operations and connections a workspace should have.
+// Specs are written in YAML or JSON and resources refer to each other by name:
+//
+//	workspace: analytics
+//	sources:
+//	  - name: pokeapi
+//	    definition: PokeAPI
+//	    configuration:
+//	      pokemon_name: snorlax
+//	destinations:
+//	  - name: local
+//	    definition: Local JSON
+//	    configuration:
+//	      destination_path: /json_data
+//	connections:
+//	  - name: pokeapi-to-local
+//	    source: pokeapi
+//	    destination: local
+//	    schedule:
+//	      units: 24
+//	      timeUnit: hours
+//	    streams:
+//	      - name: pokemon
+//	        syncMode: full_refresh
+//	        destinationSyncMode: overwrite
+//
+// Spec.Plan compares the spec with the live workspace, matching resources by name,
+// and returns the creates, updates and deletes needed to reconcile them. Plan.Apply executes them.
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/evris99/airbyte-sdk/types"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrInvalidSpec     = errors.New("invalid spec")
+	ErrAmbiguousName   = errors.New("more than one live resource has the same name")
+	ErrReplaceRequired = errors.New("resource must be replaced")
+)
+
+// The desired state of a workspace
+type Spec struct {
+	// The name of the workspace. It is created if it does not exist
+	Workspace    string            `json:"workspace"`
+	Sources      []SourceSpec      `json:"sources,omitempty"`
+	Destinations []DestinationSpec `json:"destinations,omitempty"`
+	Operations   []OperationSpec   `json:"operations,omitempty"`
+	Connections  []ConnectionSpec  `json:"connections,omitempty"`
+}
+
+// A source of the workspace
+type SourceSpec struct {
+	Name string `json:"name"`
+	// The name or ID of the source definition
+	Definition    string                 `json:"definition"`
+	Configuration map[string]interface{} `json:"configuration,omitempty"`
+}
+
+// A destination of the workspace
+type DestinationSpec struct {
+	Name string `json:"name"`
+	// The name or ID of the destination definition
+	Definition    string                 `json:"definition"`
+	Configuration map[string]interface{} `json:"configuration,omitempty"`
+}
+
+// An operation that connections of the workspace can run after their syncs
+type OperationSpec struct {
+	Name                  string                       `json:"name"`
+	OperatorConfiguration *types.OperatorConfiguration `json:"operatorConfiguration"`
+}
+
+// A connection between a source and a destination of the workspace
+type ConnectionSpec struct {
+	Name string `json:"name"`
+	// The name of the source
+	Source string `json:"source"`
+	// The name of the destination
+	Destination string `json:"destination"`
+	// The names of the operations run after each sync
+	Operations []string `json:"operations,omitempty"`
+	// The status of the connection. Defaults to active
+	Status types.ConnectionStatus `json:"status,omitempty"`
+	// The sync schedule. Connections without a schedule are synced manually
+	Schedule            *types.Schedule `json:"schedule,omitempty"`
+	NamespaceDefinition string          `json:"namespaceDefinition,omitempty"`
+	NamespaceFormat     string          `json:"namespaceFormat,omitempty"`
+	Prefix              string          `json:"prefix,omitempty"`
+	// The streams to sync. All other streams are deselected.
+	// If no streams are given the catalog of the connection is left as is
+	Streams []StreamSpec `json:"streams,omitempty"`
+}
+
+// The sync configuration of a selected stream
+type StreamSpec struct {
+	Name                string                                  `json:"name"`
+	Namespace           string                                  `json:"namespace,omitempty"`
+	SyncMode            types.SupportedSyncModesEnum            `json:"syncMode"`
+	DestinationSyncMode types.SupportedDestinationSyncModesType `json:"destinationSyncMode"`
+	// The cursor of incremental syncs. Defaults to the cursor of the source
+	CursorField []string `json:"cursorField,omitempty"`
+	// The primary key of deduplicated syncs. Defaults to the primary key of the source
+	PrimaryKey [][]string `json:"primaryKey,omitempty"`
+	Alias      string     `json:"alias,omitempty"`
+}
+
+// Parse decodes and validates a spec written in YAML or JSON
+func Parse(data []byte) (*Spec, error) {
+	var document interface{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("could not parse spec: %w", err)
+	}
+
+	// Decode through JSON, so both formats use the field names of the API
+	jsonData, err := json.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse spec: %w", err)
+	}
+
+	s := new(Spec)
+	dec := json.NewDecoder(bytes.NewReader(jsonData))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(s); err != nil {
+		return nil, fmt.Errorf("could not parse spec: %w", err)
+	}
+
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// ParseFile reads, decodes and validates the spec in the file with the given path
+func ParseFile(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read spec: %w", err)
+	}
+
+	return Parse(data)
+}
+
+// Validate checks that the names of the resources are unique and that connections refer to existing resources
+func (s *Spec) Validate() error {
+	if s.Workspace == "" {
+		return fmt.Errorf("%w: workspace name must not be empty", ErrInvalidSpec)
+	}
+
+	sources := make(map[string]bool)
+	for _, source := range s.Sources {
+		if err := addName(sources, "source", source.Name); err != nil {
+			return err
+		}
+
+		if source.Definition == "" {
+			return fmt.Errorf("%w: source %q has no definition", ErrInvalidSpec, source.Name)
+		}
+	}
+
+	destinations := make(map[string]bool)
+	for _, destination := range s.Destinations {
+		if err := addName(destinations, "destination", destination.Name); err != nil {
+			return err
+		}
+
+		if destination.Definition == "" {
+			return fmt.Errorf("%w: destination %q has no definition", ErrInvalidSpec, destination.Name)
+		}
+	}
+
+	operations := make(map[string]bool)
+	for _, operation := range s.Operations {
+		if err := addName(operations, "operation", operation.Name); err != nil {
+			return err
+		}
+
+		if operation.OperatorConfiguration == nil {
+			return fmt.Errorf("%w: operation %q has no operator configuration", ErrInvalidSpec, operation.Name)
+		}
+	}
+
+	connections := make(map[string]bool)
+	for _, conn := range s.Connections {
+		if err := addName(connections, "connection", conn.Name); err != nil {
+			return err
+		}
+
+		if !sources[conn.Source] {
+			return fmt.Errorf("%w: connection %q refers to unknown source %q", ErrInvalidSpec, conn.Name, conn.Source)
+		}
+
+		if !destinations[conn.Destination] {
+			return fmt.Errorf("%w: connection %q refers to unknown destination %q", ErrInvalidSpec, conn.Name, conn.Destination)
+		}
+
+		for _, operation := range conn.Operations {
+			if !operations[operation] {
+				return fmt.Errorf("%w: connection %q refers to unknown operation %q", ErrInvalidSpec, conn.Name, operation)
+			}
+		}
+	}
+
+	return nil
+}
+
+func addName(names map[string]bool, kind, name string) error {
+	if name == "" {
+		return fmt.Errorf("%w: %s name must not be empty", ErrInvalidSpec, kind)
+	}
+
+	if names[name] {
+		return fmt.Errorf("%w: duplicate %s %q", ErrInvalidSpec, kind, name)
+	}
+
+	names[name] = true
+	return nil
+}
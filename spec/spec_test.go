@@ -0,0 +1,67 @@
+package spec
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/evris99/airbyte-sdk/types"
+)
+
+func TestParseFile(t *testing.T) {
+	s, err := ParseFile("testdata/workspace.yaml")
+	if err != nil {
+		t.Fatalf("could not parse spec: %v", err)
+	}
+
+	if s.Workspace != "analytics" || len(s.Sources) != 1 || len(s.Destinations) != 1 || len(s.Connections) != 1 {
+		t.Fatalf("unexpected spec: %+v", s)
+	}
+
+	if s.Sources[0].Configuration["pokemon_name"] != "snorlax" {
+		t.Fatalf("unexpected source configuration: %v", s.Sources[0].Configuration)
+	}
+
+	if s.Operations[0].OperatorConfiguration.Normalization.Option != types.BasicNormalization {
+		t.Fatalf("unexpected operation: %+v", s.Operations[0])
+	}
+
+	conn := s.Connections[0]
+	if conn.Schedule.TimeUnit != types.Hours || conn.Streams[0].DestinationSyncMode != types.Overwrite {
+		t.Fatalf("unexpected connection: %+v", conn)
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	s, err := Parse([]byte(`{"workspace": "analytics", "sources": [{"name": "pokeapi", "definition": "PokeAPI"}]}`))
+	if err != nil {
+		t.Fatalf("could not parse spec: %v", err)
+	}
+
+	if len(s.Sources) != 1 || s.Sources[0].Definition != "PokeAPI" {
+		t.Fatalf("unexpected spec: %+v", s)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := map[string]string{
+		"unknown field":       "workspace: a\nsources: [{name: s, definition: d, config: {}}]",
+		"missing workspace":   "sources: []",
+		"duplicate source":    "workspace: a\nsources: [{name: s, definition: d}, {name: s, definition: d}]",
+		"unknown source":      "workspace: a\nconnections: [{name: c, source: s, destination: d}]",
+		"missing definition":  "workspace: a\ndestinations: [{name: d}]",
+		"unknown operation":   "workspace: a\nsources: [{name: s, definition: d}]\ndestinations: [{name: d, definition: d}]\nconnections: [{name: c, source: s, destination: d, operations: [o]}]",
+		"malformed yaml":      "workspace: [",
+		"missing operator":    "workspace: a\noperations: [{name: o}]",
+		"empty resource name": "workspace: a\nsources: [{definition: d}]",
+	}
+
+	for name, document := range tests {
+		if _, err := Parse([]byte(document)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	if _, err := Parse([]byte("workspace: a\nsources: [{name: s}]")); !errors.Is(err, ErrInvalidSpec) {
+		t.Fatalf("expected invalid spec error, got: %v", err)
+	}
+}
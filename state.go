@@ -0,0 +1,48 @@
+package airbytesdk
+
+import (
+	"context"
+
+	"github.com/evris99/airbyte-sdk/types"
+	"github.com/google/uuid"
+)
+
+// GetConnectionState returns the state of the connection with the given ID
+func (c *Client) GetConnectionState(ctx context.Context, connectionID *uuid.UUID) (*types.ConnectionState, error) {
+	u, err := appendToURL(c.endpoint, "/v1/state/get")
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]*uuid.UUID)
+	data["connectionId"] = connectionID
+
+	res, err := c.makeRequest(ctx, u, data)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	return types.ConnectionStateFromJSON(res.Body)
+}
+
+// CreateOrUpdateConnectionState replaces the state of the connection with the given ID.
+// It can be used to move the incremental cursors of the connection without resetting it
+func (c *Client) CreateOrUpdateConnectionState(ctx context.Context, connectionID *uuid.UUID, state *types.ConnectionState) (*types.ConnectionState, error) {
+	u, err := appendToURL(c.endpoint, "/v1/state/create_or_update")
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]interface{})
+	data["connectionId"] = connectionID
+	data["connectionState"] = state
+
+	res, err := c.makeRequest(ctx, u, data)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	return types.ConnectionStateFromJSON(res.Body)
+}
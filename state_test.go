@@ -0,0 +1,58 @@
+package airbytesdk
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/evris99/airbyte-sdk/airbytetest"
+	"github.com/evris99/airbyte-sdk/types"
+)
+
+func TestConnectionState(t *testing.T) {
+	srv := airbytetest.NewServer()
+	defer srv.Close()
+
+	airbyte, err := New(srv.Endpoint())
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	ctx := context.Background()
+	conn := createTestConnection(t, airbyte)
+
+	state, err := airbyte.GetConnectionState(ctx, conn.ConnectionId)
+	if err != nil {
+		t.Fatalf("could not get state: %v", err)
+	}
+
+	if state.StateType != types.NotSetStateType {
+		t.Fatalf("expected no state, got %s", state.StateType)
+	}
+
+	state = &types.ConnectionState{
+		StateType: types.StreamStateType,
+		StreamState: []types.StreamState{{
+			StreamDescriptor: &types.StreamDescriptor{Name: "users", Namespace: "public"},
+			StreamState:      map[string]interface{}{"cursor": json.Number("9007199254740993")},
+		}},
+	}
+
+	if _, err := airbyte.CreateOrUpdateConnectionState(ctx, conn.ConnectionId, state); err != nil {
+		t.Fatalf("could not update state: %v", err)
+	}
+
+	state, err = airbyte.GetConnectionState(ctx, conn.ConnectionId)
+	if err != nil {
+		t.Fatalf("could not get state: %v", err)
+	}
+
+	users := state.FindStream("users", "public")
+	if users == nil {
+		t.Fatal("could not find the state of the users stream")
+	}
+
+	if cursor := users.StreamState["cursor"]; cursor != json.Number("9007199254740993") {
+		t.Fatalf("cursor lost its precision: %v", cursor)
+	}
+}
@@ -0,0 +1,290 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrStreamNotFound          = errors.New("stream not found in catalog")
+	ErrUnsupportedSyncMode     = errors.New("sync mode is not supported by the stream")
+	ErrSourceDefinedCursor     = errors.New("the cursor is defined by the source")
+	ErrSourceDefinedPrimaryKey = errors.New("the primary key is defined by the source")
+	ErrUnknownField            = errors.New("field does not exist in the stream schema")
+	ErrMissingCursor           = errors.New("incremental sync requires a cursor field")
+	ErrMissingPrimaryKey       = errors.New("deduplicated sync requires a primary key")
+	ErrMissingSyncMode         = errors.New("selected streams require a sync mode and a destination sync mode")
+)
+
+// Find returns the stream with the given name and namespace or nil if it does not exist
+func (c *SyncCatalogType) Find(name, namespace string) *StreamAndConfiguration {
+	for i := range c.Streams {
+		stream := c.Streams[i].Stream
+		if stream != nil && stream.Name == name && stream.Namespace == namespace {
+			return &c.Streams[i]
+		}
+	}
+
+	return nil
+}
+
+// Copy returns a deep copy of the catalog
+func (c *SyncCatalogType) Copy() (*SyncCatalogType, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := new(SyncCatalogType)
+	err = json.Unmarshal(data, catalog)
+	return catalog, err
+}
+
+// A CatalogBuilder configures the streams of a catalog.
+// Every change is validated against the modes, cursor and primary key the stream supports.
+// The first failed change is kept and returned by Build, while all later changes are ignored
+type CatalogBuilder struct {
+	catalog *SyncCatalogType
+	err     error
+}
+
+// NewCatalogBuilder returns a builder that configures a copy of the given catalog
+func NewCatalogBuilder(catalog *SyncCatalogType) *CatalogBuilder {
+	b := new(CatalogBuilder)
+	b.catalog, b.err = catalog.Copy()
+	return b
+}
+
+// Stream returns a builder for the stream with the given name and namespace
+func (b *CatalogBuilder) Stream(name, namespace string) *StreamBuilder {
+	sb := &StreamBuilder{parent: b}
+	if b.err != nil {
+		return sb
+	}
+
+	sb.stream = b.catalog.Find(name, namespace)
+	if sb.stream == nil || sb.stream.Stream == nil {
+		b.err = streamError(name, namespace, ErrStreamNotFound)
+		return sb
+	}
+
+	if sb.stream.Config == nil {
+		sb.stream.Config = new(Config)
+	}
+
+	return sb
+}
+
+// Build validates the selected streams and returns the configured catalog
+func (b *CatalogBuilder) Build() (*SyncCatalogType, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	for i := range b.catalog.Streams {
+		if err := completeStreamConfig(&b.catalog.Streams[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return b.catalog, nil
+}
+
+// A StreamBuilder configures a single stream of a catalog
+type StreamBuilder struct {
+	parent *CatalogBuilder
+	stream *StreamAndConfiguration
+}
+
+// Select includes the stream in the sync
+func (sb *StreamBuilder) Select() *StreamBuilder {
+	if sb.ok() {
+		sb.stream.Config.Selected = true
+	}
+
+	return sb
+}
+
+// Deselect excludes the stream from the sync
+func (sb *StreamBuilder) Deselect() *StreamBuilder {
+	if sb.ok() {
+		sb.stream.Config.Selected = false
+	}
+
+	return sb
+}
+
+// SyncMode sets the source and destination sync modes of the stream
+func (sb *StreamBuilder) SyncMode(mode SupportedSyncModesEnum, destinationMode SupportedDestinationSyncModesType) *StreamBuilder {
+	if !sb.ok() {
+		return sb
+	}
+
+	if !sb.supports(mode) {
+		return sb.fail(fmt.Errorf("%w: %s", ErrUnsupportedSyncMode, mode))
+	}
+
+	sb.stream.Config.SyncMode = mode
+	sb.stream.Config.DestinationSyncMode = destinationMode
+	return sb
+}
+
+// CursorField sets the field used as a cursor for incremental syncs.
+// Nested fields are given as a path
+func (sb *StreamBuilder) CursorField(path ...string) *StreamBuilder {
+	if !sb.ok() {
+		return sb
+	}
+
+	if sb.stream.Stream.SourceDefinedCursor {
+		return sb.fail(ErrSourceDefinedCursor)
+	}
+
+	if !schemaHasField(sb.stream.Stream.JsonSchema, path) {
+		return sb.fail(fmt.Errorf("%w: %v", ErrUnknownField, path))
+	}
+
+	sb.stream.Config.CursorField = path
+	return sb
+}
+
+// PrimaryKey sets the primary key used for deduplication.
+// Each key is the path of a field and composite keys are given as multiple paths
+func (sb *StreamBuilder) PrimaryKey(keys ...[]string) *StreamBuilder {
+	if !sb.ok() {
+		return sb
+	}
+
+	if len(sb.stream.Stream.SourceDefinedPrimaryKey) > 0 {
+		return sb.fail(ErrSourceDefinedPrimaryKey)
+	}
+
+	for _, key := range keys {
+		if !schemaHasField(sb.stream.Stream.JsonSchema, key) {
+			return sb.fail(fmt.Errorf("%w: %v", ErrUnknownField, key))
+		}
+	}
+
+	sb.stream.Config.PrimaryKey = keys
+	return sb
+}
+
+// Alias sets the name of the stream in the destination
+func (sb *StreamBuilder) Alias(name string) *StreamBuilder {
+	if sb.ok() {
+		sb.stream.Config.AliasName = name
+	}
+
+	return sb
+}
+
+// Stream returns a builder for another stream of the same catalog
+func (sb *StreamBuilder) Stream(name, namespace string) *StreamBuilder {
+	return sb.parent.Stream(name, namespace)
+}
+
+// Build validates the selected streams and returns the configured catalog
+func (sb *StreamBuilder) Build() (*SyncCatalogType, error) {
+	return sb.parent.Build()
+}
+
+// Returns true if no change has failed so far
+func (sb *StreamBuilder) ok() bool {
+	return sb.parent.err == nil && sb.stream != nil
+}
+
+// Records the error for the stream and returns the builder
+func (sb *StreamBuilder) fail(err error) *StreamBuilder {
+	sb.parent.err = streamError(sb.stream.Stream.Name, sb.stream.Stream.Namespace, err)
+	return sb
+}
+
+func (sb *StreamBuilder) supports(mode SupportedSyncModesEnum) bool {
+	return sb.stream.Stream.SupportsSyncMode(mode)
+}
+
+// SupportsSyncMode returns true if the stream supports the sync mode.
+// Streams that do not list their supported sync modes support any mode
+func (s *StreamType) SupportsSyncMode(mode SupportedSyncModesEnum) bool {
+	// Nothing to validate against
+	if len(s.SupportedSyncModes) == 0 {
+		return true
+	}
+
+	for _, supported := range s.SupportedSyncModes {
+		if supported == mode {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Fills in the cursor and primary key defined by the source
+// and checks that a selected stream has supported sync modes and everything they need
+func completeStreamConfig(s *StreamAndConfiguration) error {
+	if s.Stream == nil || s.Config == nil || !s.Config.Selected {
+		return nil
+	}
+
+	if s.Config.SyncMode == "" || s.Config.DestinationSyncMode == "" {
+		return streamError(s.Stream.Name, s.Stream.Namespace, ErrMissingSyncMode)
+	}
+
+	if !s.Stream.SupportsSyncMode(s.Config.SyncMode) {
+		return streamError(s.Stream.Name, s.Stream.Namespace, fmt.Errorf("%w: %s", ErrUnsupportedSyncMode, s.Config.SyncMode))
+	}
+
+	if s.Config.SyncMode == Incremental {
+		if s.Stream.SourceDefinedCursor || len(s.Config.CursorField) == 0 {
+			s.Config.CursorField = s.Stream.DefaultCursorField
+		}
+
+		if len(s.Config.CursorField) == 0 && !s.Stream.SourceDefinedCursor {
+			return streamError(s.Stream.Name, s.Stream.Namespace, ErrMissingCursor)
+		}
+	}
+
+	if s.Config.DestinationSyncMode == AppendDedup {
+		if len(s.Stream.SourceDefinedPrimaryKey) > 0 {
+			s.Config.PrimaryKey = s.Stream.SourceDefinedPrimaryKey
+		}
+
+		if len(s.Config.PrimaryKey) == 0 {
+			return streamError(s.Stream.Name, s.Stream.Namespace, ErrMissingPrimaryKey)
+		}
+	}
+
+	return nil
+}
+
+func streamError(name, namespace string, err error) error {
+	if namespace != "" {
+		name = namespace + "." + name
+	}
+
+	return fmt.Errorf("stream %q: %w", name, err)
+}
+
+// Returns true if the field with the given path exists in the JSON schema.
+// Schemas that do not declare their properties accept any field
+func schemaHasField(schema map[string]interface{}, path []string) bool {
+	if len(path) == 0 {
+		return false
+	}
+
+	for _, name := range path {
+		properties, ok := schema["properties"].(map[string]interface{})
+		if !ok {
+			return true
+		}
+
+		schema, ok = properties[name].(map[string]interface{})
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,330 @@
+package types
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// A field of a stream whose schema changed
+type FieldChange struct {
+	// The path of the field in the stream, for example ["address", "city"]
+	Path []string
+	// The type of the field in the old catalog. Empty for added fields
+	OldType string
+	// The type of the field in the new catalog. Empty for removed fields
+	NewType string
+}
+
+// A change of the primary key defined by the source of a stream
+type PrimaryKeyChange struct {
+	Old [][]string
+	New [][]string
+}
+
+// A change of the default cursor field of a stream
+type CursorChange struct {
+	Old []string
+	New []string
+}
+
+// The changes of a stream that exists in both catalogs
+type StreamChanges struct {
+	Stream            StreamDescriptor
+	AddedFields       []FieldChange
+	RemovedFields     []FieldChange
+	TypeChangedFields []FieldChange
+	// Set if the primary key defined by the source changed
+	PrimaryKey *PrimaryKeyChange
+	// Set if the default cursor field of the stream changed
+	CursorField *CursorChange
+}
+
+// The differences between two catalogs, as computed by DiffCatalogs
+type CatalogChanges struct {
+	AddedStreams   []StreamDescriptor
+	RemovedStreams []StreamDescriptor
+	UpdatedStreams []StreamChanges
+}
+
+// DiffCatalogs compares the streams of two catalogs, such as the catalog of a connection
+// and a newly discovered catalog of its source. The configuration of the streams is ignored
+func DiffCatalogs(from, to *SyncCatalogType) *CatalogChanges {
+	if from == nil {
+		from = &SyncCatalogType{}
+	}
+
+	if to == nil {
+		to = &SyncCatalogType{}
+	}
+
+	changes := new(CatalogChanges)
+	for _, s := range to.Streams {
+		if s.Stream != nil && from.Find(s.Stream.Name, s.Stream.Namespace) == nil {
+			changes.AddedStreams = append(changes.AddedStreams, descriptorOf(s.Stream))
+		}
+	}
+
+	for _, s := range from.Streams {
+		if s.Stream == nil {
+			continue
+		}
+
+		updated := to.Find(s.Stream.Name, s.Stream.Namespace)
+		if updated == nil || updated.Stream == nil {
+			changes.RemovedStreams = append(changes.RemovedStreams, descriptorOf(s.Stream))
+			continue
+		}
+
+		if streamChanges := diffStreams(s.Stream, updated.Stream); !streamChanges.Empty() {
+			changes.UpdatedStreams = append(changes.UpdatedStreams, *streamChanges)
+		}
+	}
+
+	sortDescriptors(changes.AddedStreams)
+	sortDescriptors(changes.RemovedStreams)
+	sort.Slice(changes.UpdatedStreams, func(i, j int) bool {
+		return changes.UpdatedStreams[i].Stream.String() < changes.UpdatedStreams[j].Stream.String()
+	})
+
+	return changes
+}
+
+// Empty returns true if the catalogs have the same streams with the same schemas
+func (c *CatalogChanges) Empty() bool {
+	return len(c.AddedStreams) == 0 && len(c.RemovedStreams) == 0 && len(c.UpdatedStreams) == 0
+}
+
+// Breaking returns true if a stream or field was removed or changed in a way
+// that can break the syncs or the consumers of the data
+func (c *CatalogChanges) Breaking() bool {
+	if len(c.RemovedStreams) > 0 {
+		return true
+	}
+
+	for i := range c.UpdatedStreams {
+		if c.UpdatedStreams[i].Breaking() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String renders the changes in a human readable form, one change per line
+func (c *CatalogChanges) String() string {
+	var b strings.Builder
+	for _, s := range c.AddedStreams {
+		fmt.Fprintf(&b, "+ stream %s\n", s)
+	}
+
+	for _, s := range c.RemovedStreams {
+		fmt.Fprintf(&b, "- stream %s\n", s)
+	}
+
+	for i := range c.UpdatedStreams {
+		b.WriteString(c.UpdatedStreams[i].String())
+	}
+
+	return b.String()
+}
+
+// Empty returns true if the stream did not change
+func (s *StreamChanges) Empty() bool {
+	return len(s.AddedFields) == 0 && len(s.RemovedFields) == 0 && len(s.TypeChangedFields) == 0 &&
+		s.PrimaryKey == nil && s.CursorField == nil
+}
+
+// Breaking returns true if a field was removed or changed type, or the primary key or cursor changed
+func (s *StreamChanges) Breaking() bool {
+	return len(s.RemovedFields) > 0 || len(s.TypeChangedFields) > 0 || s.PrimaryKey != nil || s.CursorField != nil
+}
+
+// String renders the changes of the stream in a human readable form, one change per line
+func (s *StreamChanges) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "~ stream %s\n", s.Stream)
+
+	for _, f := range s.AddedFields {
+		fmt.Fprintf(&b, "    + field %s (%s)\n", strings.Join(f.Path, "."), f.NewType)
+	}
+
+	for _, f := range s.RemovedFields {
+		fmt.Fprintf(&b, "    - field %s (%s)\n", strings.Join(f.Path, "."), f.OldType)
+	}
+
+	for _, f := range s.TypeChangedFields {
+		fmt.Fprintf(&b, "    ~ field %s: %s -> %s\n", strings.Join(f.Path, "."), f.OldType, f.NewType)
+	}
+
+	if s.PrimaryKey != nil {
+		fmt.Fprintf(&b, "    ~ primary key: %s -> %s\n", formatKey(s.PrimaryKey.Old), formatKey(s.PrimaryKey.New))
+	}
+
+	if s.CursorField != nil {
+		fmt.Fprintf(&b, "    ~ cursor: %s -> %s\n", formatPath(s.CursorField.Old), formatPath(s.CursorField.New))
+	}
+
+	return b.String()
+}
+
+// String returns the stream name prefixed with its namespace, if any
+func (d StreamDescriptor) String() string {
+	if d.Namespace == "" {
+		return d.Name
+	}
+
+	return d.Namespace + "." + d.Name
+}
+
+func diffStreams(from, to *StreamType) *StreamChanges {
+	changes := &StreamChanges{Stream: descriptorOf(from)}
+
+	oldFields := make(map[string]FieldChange)
+	collectFields(from.JsonSchema, nil, oldFields)
+	newFields := make(map[string]FieldChange)
+	collectFields(to.JsonSchema, nil, newFields)
+
+	for key, field := range newFields {
+		old, ok := oldFields[key]
+		switch {
+		case !ok:
+			changes.AddedFields = append(changes.AddedFields, FieldChange{Path: field.Path, NewType: field.NewType})
+		case old.NewType != field.NewType:
+			changes.TypeChangedFields = append(changes.TypeChangedFields, FieldChange{Path: field.Path, OldType: old.NewType, NewType: field.NewType})
+		}
+	}
+
+	for key, field := range oldFields {
+		if _, ok := newFields[key]; !ok {
+			changes.RemovedFields = append(changes.RemovedFields, FieldChange{Path: field.Path, OldType: field.NewType})
+		}
+	}
+
+	sortFields(changes.AddedFields)
+	sortFields(changes.RemovedFields)
+	sortFields(changes.TypeChangedFields)
+
+	if !equalKeys(from.SourceDefinedPrimaryKey, to.SourceDefinedPrimaryKey) {
+		changes.PrimaryKey = &PrimaryKeyChange{Old: from.SourceDefinedPrimaryKey, New: to.SourceDefinedPrimaryKey}
+	}
+
+	if !equalPaths(from.DefaultCursorField, to.DefaultCursorField) {
+		changes.CursorField = &CursorChange{Old: from.DefaultCursorField, New: to.DefaultCursorField}
+	}
+
+	return changes
+}
+
+// Adds every field of the JSON schema, including the fields of nested objects and arrays of objects,
+// to the map keyed by its path. The type of each field is kept in NewType
+func collectFields(schema map[string]interface{}, parent []string, fields map[string]FieldChange) {
+	if items, ok := schema["items"].(map[string]interface{}); ok {
+		collectFields(items, parent, fields)
+	}
+
+	properties, ok := schema["properties"].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	for name, value := range properties {
+		fieldSchema, _ := value.(map[string]interface{})
+		path := append(append([]string(nil), parent...), name)
+
+		fields[strings.Join(path, "\x00")] = FieldChange{Path: path, NewType: schemaType(fieldSchema)}
+		collectFields(fieldSchema, path, fields)
+	}
+}
+
+// Returns a short description of the type of a JSON schema, such as "integer|null" or "array<string>"
+func schemaType(schema map[string]interface{}) string {
+	var names []string
+	switch t := schema["type"].(type) {
+	case string:
+		names = append(names, t)
+	case []interface{}:
+		for _, name := range t {
+			if s, ok := name.(string); ok {
+				names = append(names, s)
+			}
+		}
+	}
+	sort.Strings(names)
+
+	for _, key := range []string{"anyOf", "oneOf"} {
+		if options, ok := schema[key].([]interface{}); ok {
+			var types []string
+			for _, option := range options {
+				optionSchema, _ := option.(map[string]interface{})
+				types = append(types, schemaType(optionSchema))
+			}
+			names = append(names, key+"("+strings.Join(types, ",")+")")
+		}
+	}
+
+	description := strings.Join(names, "|")
+	if format, ok := schema["format"].(string); ok {
+		description += "(" + format + ")"
+	}
+
+	if airbyteType, ok := schema["airbyte_type"].(string); ok {
+		description += "(" + airbyteType + ")"
+	}
+
+	if items, ok := schema["items"].(map[string]interface{}); ok {
+		description += "<" + schemaType(items) + ">"
+	}
+
+	if description == "" {
+		return "unknown"
+	}
+
+	return description
+}
+
+func descriptorOf(stream *StreamType) StreamDescriptor {
+	return StreamDescriptor{Name: stream.Name, Namespace: stream.Namespace}
+}
+
+func sortDescriptors(descriptors []StreamDescriptor) {
+	sort.Slice(descriptors, func(i, j int) bool { return descriptors[i].String() < descriptors[j].String() })
+}
+
+func sortFields(fields []FieldChange) {
+	sort.Slice(fields, func(i, j int) bool {
+		return strings.Join(fields[i].Path, ".") < strings.Join(fields[j].Path, ".")
+	})
+}
+
+// Compares keys treating nil and empty keys as equal
+func equalKeys(a, b [][]string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// Compares paths treating nil and empty paths as equal
+func equalPaths(a, b []string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+func formatKey(key [][]string) string {
+	paths := make([]string, 0, len(key))
+	for _, path := range key {
+		paths = append(paths, strings.Join(path, "."))
+	}
+
+	return "[" + strings.Join(paths, ", ") + "]"
+}
+
+func formatPath(path []string) string {
+	return "[" + strings.Join(path, ".") + "]"
+}
@@ -0,0 +1,121 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffCatalogsEqual(t *testing.T) {
+	changes := DiffCatalogs(readCatalog(t), readCatalog(t))
+	if !changes.Empty() || changes.Breaking() {
+		t.Fatalf("expected no changes, got:\n%s", changes)
+	}
+}
+
+func TestDiffCatalogsStreams(t *testing.T) {
+	old := readCatalog(t)
+	updated := readCatalog(t)
+
+	updated.Streams = updated.Streams[1:]
+	updated.Streams = append(updated.Streams, StreamAndConfiguration{
+		Stream: &StreamType{Name: "orders", Namespace: "public"},
+	})
+
+	changes := DiffCatalogs(old, updated)
+
+	if !reflect.DeepEqual(changes.AddedStreams, []StreamDescriptor{{Name: "orders", Namespace: "public"}}) {
+		t.Fatalf("unexpected added streams: %v", changes.AddedStreams)
+	}
+
+	if !reflect.DeepEqual(changes.RemovedStreams, []StreamDescriptor{{Name: "pokemon"}}) {
+		t.Fatalf("unexpected removed streams: %v", changes.RemovedStreams)
+	}
+
+	if len(changes.UpdatedStreams) != 0 {
+		t.Fatalf("unexpected updated streams: %v", changes.UpdatedStreams)
+	}
+
+	if !changes.Breaking() {
+		t.Fatal("removing a stream should be breaking")
+	}
+}
+
+func TestDiffCatalogsFields(t *testing.T) {
+	old := readCatalog(t)
+	updated := readCatalog(t)
+
+	pokemon := updated.Find("pokemon", "").Stream
+	properties := pokemon.JsonSchema["properties"].(map[string]interface{})
+	delete(properties, "height")
+	properties["weight"] = map[string]interface{}{"type": []interface{}{"null", "number"}}
+	properties["id"] = map[string]interface{}{"type": []interface{}{"null", "string"}}
+	abilities := properties["abilities"].(map[string]interface{})["items"].(map[string]interface{})
+	abilities["properties"].(map[string]interface{})["name"] = map[string]interface{}{"type": "string"}
+
+	changes := DiffCatalogs(old, updated)
+	if len(changes.UpdatedStreams) != 1 {
+		t.Fatalf("expected one updated stream, got:\n%s", changes)
+	}
+
+	stream := changes.UpdatedStreams[0]
+	expected := StreamChanges{
+		Stream: StreamDescriptor{Name: "pokemon"},
+		AddedFields: []FieldChange{
+			{Path: []string{"abilities", "name"}, NewType: "string"},
+			{Path: []string{"weight"}, NewType: "null|number"},
+		},
+		RemovedFields: []FieldChange{
+			{Path: []string{"height"}, OldType: "integer|null"},
+		},
+		TypeChangedFields: []FieldChange{
+			{Path: []string{"id"}, OldType: "integer|null", NewType: "null|string"},
+		},
+	}
+
+	if !reflect.DeepEqual(stream, expected) {
+		t.Fatalf("unexpected stream changes:\n%s", &stream)
+	}
+
+	if !changes.Breaking() {
+		t.Fatal("removing a field should be breaking")
+	}
+}
+
+func TestDiffCatalogsAddedFieldsOnly(t *testing.T) {
+	old := readCatalog(t)
+	updated := readCatalog(t)
+
+	users := updated.Find("users", "public").Stream
+	users.JsonSchema["properties"].(map[string]interface{})["name"] = map[string]interface{}{"type": "string"}
+
+	changes := DiffCatalogs(old, updated)
+	if changes.Empty() {
+		t.Fatal("expected changes")
+	}
+
+	if changes.Breaking() {
+		t.Fatalf("adding a field should not be breaking:\n%s", changes)
+	}
+}
+
+func TestDiffCatalogsKeys(t *testing.T) {
+	old := readCatalog(t)
+	updated := readCatalog(t)
+
+	users := updated.Find("users", "public").Stream
+	users.SourceDefinedPrimaryKey = [][]string{{"id"}, {"email"}}
+	users.DefaultCursorField = []string{"id"}
+
+	changes := DiffCatalogs(old, updated)
+
+	expected := "~ stream public.users\n" +
+		"    ~ primary key: [id] -> [id, email]\n" +
+		"    ~ cursor: [updated_at] -> [id]\n"
+	if changes.String() != expected {
+		t.Fatalf("unexpected diff:\n%s", changes)
+	}
+
+	if !changes.Breaking() {
+		t.Fatal("changing the primary key should be breaking")
+	}
+}
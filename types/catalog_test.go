@@ -0,0 +1,111 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func readCatalog(t *testing.T) *SyncCatalogType {
+	t.Helper()
+
+	data, err := os.ReadFile("testdata/catalog.json")
+	if err != nil {
+		t.Fatalf("could not read catalog: %v", err)
+	}
+
+	catalog := new(SyncCatalogType)
+	if err := json.Unmarshal(data, catalog); err != nil {
+		t.Fatalf("could not decode catalog: %v", err)
+	}
+
+	return catalog
+}
+
+func TestCatalogBuilder(t *testing.T) {
+	original := readCatalog(t)
+
+	catalog, err := NewCatalogBuilder(original).
+		Stream("pokemon", "").Deselect().
+		Stream("users", "public").Select().SyncMode(Incremental, AppendDedup).Alias("customers").
+		Build()
+	if err != nil {
+		t.Fatalf("could not build catalog: %v", err)
+	}
+
+	if catalog.Find("pokemon", "").Config.Selected {
+		t.Fatal("pokemon stream should be deselected")
+	}
+
+	users := catalog.Find("users", "public").Config
+	if !users.Selected || users.SyncMode != Incremental || users.DestinationSyncMode != AppendDedup || users.AliasName != "customers" {
+		t.Fatalf("unexpected users config: %+v", users)
+	}
+
+	if !reflect.DeepEqual(users.PrimaryKey, [][]string{{"id"}}) || !reflect.DeepEqual(users.CursorField, []string{"updated_at"}) {
+		t.Fatalf("expected the source defined cursor and primary key, got %v and %v", users.CursorField, users.PrimaryKey)
+	}
+
+	if !original.Find("pokemon", "").Config.Selected {
+		t.Fatal("the builder should not modify the original catalog")
+	}
+}
+
+func TestCatalogBuilderValidation(t *testing.T) {
+	catalog := readCatalog(t)
+
+	tests := []struct {
+		name  string
+		build func(b *CatalogBuilder) *StreamBuilder
+		err   error
+	}{
+		{"missing stream", func(b *CatalogBuilder) *StreamBuilder { return b.Stream("users", "") }, ErrStreamNotFound},
+		{"unsupported mode", func(b *CatalogBuilder) *StreamBuilder {
+			return b.Stream("pokemon", "").SyncMode(Incremental, Append)
+		}, ErrUnsupportedSyncMode},
+		{"source cursor", func(b *CatalogBuilder) *StreamBuilder {
+			return b.Stream("users", "public").CursorField("id")
+		}, ErrSourceDefinedCursor},
+		{"source primary key", func(b *CatalogBuilder) *StreamBuilder {
+			return b.Stream("users", "public").PrimaryKey([]string{"email"})
+		}, ErrSourceDefinedPrimaryKey},
+		{"unknown field", func(b *CatalogBuilder) *StreamBuilder {
+			return b.Stream("pokemon", "").PrimaryKey([]string{"weight"})
+		}, ErrUnknownField},
+		{"missing primary key", func(b *CatalogBuilder) *StreamBuilder {
+			return b.Stream("pokemon", "").Select().SyncMode(FullRefresh, AppendDedup)
+		}, ErrMissingPrimaryKey},
+		{"missing sync mode", func(b *CatalogBuilder) *StreamBuilder {
+			sb := b.Stream("pokemon", "").Select()
+			sb.stream.Config.SyncMode = ""
+			return sb
+		}, ErrMissingSyncMode},
+		{"missing destination sync mode", func(b *CatalogBuilder) *StreamBuilder {
+			sb := b.Stream("pokemon", "").Select().SyncMode(FullRefresh, Append)
+			sb.stream.Config.DestinationSyncMode = ""
+			return sb
+		}, ErrMissingSyncMode},
+		{"unsupported selected mode", func(b *CatalogBuilder) *StreamBuilder {
+			sb := b.Stream("pokemon", "").Select().SyncMode(FullRefresh, Append)
+			sb.stream.Config.SyncMode = Incremental
+			return sb
+		}, ErrUnsupportedSyncMode},
+	}
+
+	for _, test := range tests {
+		_, err := test.build(NewCatalogBuilder(catalog)).Build()
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.err, err)
+		}
+	}
+
+	// Primary keys that exist in the schema are accepted
+	_, err := NewCatalogBuilder(catalog).
+		Stream("pokemon", "").Select().SyncMode(FullRefresh, AppendDedup).PrimaryKey([]string{"id"}).
+		Build()
+	if err != nil {
+		t.Fatalf("could not build catalog: %v", err)
+	}
+}
@@ -3,173 +3,72 @@ package types
 import (
 	"encoding/json"
 	"io"
-	"strings"
 
 	"github.com/google/uuid"
 )
 
-type SupportedSyncModesEnum int
+// The sync mode of a source stream
+type SupportedSyncModesEnum string
 
 const (
-	FullRefresh SupportedSyncModesEnum = iota + 1
-	Incremental
+	FullRefresh SupportedSyncModesEnum = "full_refresh"
+	Incremental SupportedSyncModesEnum = "incremental"
 )
 
-// Unmarshaler for json
-func (su *SupportedSyncModesEnum) UnmarshalJSON(b []byte) error {
-	var s string
-	if err := json.Unmarshal(b, &s); err != nil {
-		return err
-	}
-
-	switch strings.ToLower(s) {
-	case "full_refresh":
-		*su = FullRefresh
-	case "incremental":
-		*su = Incremental
-	}
-
-	return nil
-}
-
-// Marshaler for json
-func (su SupportedSyncModesEnum) MarshalJSON() ([]byte, error) {
-	var s string
-	switch su {
-	case FullRefresh:
-		s = "full_refresh"
-	case Incremental:
-		s = "incremental"
-	}
-
-	return json.Marshal(s)
-}
-
 type StreamType struct {
-	Name                    string                 `json:"name,omitempty"`
-	JsonSchema              map[string]interface{} `json:"jsonSchema,omitempty"`
-	SupportedSyncModes      SupportedSyncModesEnum `json:"supportedSyncModes,omitempty"`
-	SourceDefinedCursor     bool                   `json:"sourceDefinedCursor,omitempty"`
-	DefaultCursorField      []string               `json:"defaultCursorField,omitempty"`
-	SourceDefinedPrimaryKey [][]string             `json:"sourceDefinedPrimaryKey,omitempty"`
-	Namespace               string                 `json:"namespace,omitempty"`
+	Name                    string                   `json:"name,omitempty"`
+	JsonSchema              map[string]interface{}   `json:"jsonSchema,omitempty"`
+	SupportedSyncModes      []SupportedSyncModesEnum `json:"supportedSyncModes"`
+	SourceDefinedCursor     bool                     `json:"sourceDefinedCursor"`
+	DefaultCursorField      []string                 `json:"defaultCursorField"`
+	SourceDefinedPrimaryKey [][]string               `json:"sourceDefinedPrimaryKey"`
+	Namespace               string                   `json:"namespace,omitempty"`
 }
 
 type Config struct {
 	SyncMode            SupportedSyncModesEnum            `json:"syncMode,omitempty"`
-	CursorField         []string                          `json:"cursorField,omitempty"`
+	CursorField         []string                          `json:"cursorField"`
 	DestinationSyncMode SupportedDestinationSyncModesType `json:"destinationSyncMode,omitempty"`
-	PrimaryKey          [][]string                        `json:"primaryKey,omitempty"`
+	PrimaryKey          [][]string                        `json:"primaryKey"`
 	AliasName           string                            `json:"aliasName,omitempty"`
-	Selected            bool                              `json:"selected,omitempty"`
+	Selected            bool                              `json:"selected"`
+}
+
+// A stream of a catalog together with its sync configuration
+type StreamAndConfiguration struct {
+	Stream *StreamType `json:"stream,omitempty"`
+	Config *Config     `json:"config,omitempty"`
 }
 
 type SyncCatalogType struct {
-	Streams []struct {
-		Stream *StreamType `json:"stream,omitempty"`
-		Config *Config     `json:"config,omitempty"`
-	} `json:"streams,omitempty"`
+	Streams []StreamAndConfiguration `json:"streams,omitempty"`
 }
 
-type TimeUnit int
+// The unit of a connection schedule
+type TimeUnit string
 
 const (
-	Minutes TimeUnit = iota + 1
-	Hours
-	Days
-	Weeks
-	Months
+	Minutes TimeUnit = "minutes"
+	Hours   TimeUnit = "hours"
+	Days    TimeUnit = "days"
+	Weeks   TimeUnit = "weeks"
+	Months  TimeUnit = "months"
 )
 
-// Unmarshaler for json
-func (t *TimeUnit) UnmarshalJSON(b []byte) error {
-	var s string
-	if err := json.Unmarshal(b, &s); err != nil {
-		return err
-	}
-
-	switch strings.ToLower(s) {
-	case "minutes":
-		*t = Minutes
-	case "hours":
-		*t = Hours
-	case "days":
-		*t = Days
-	case "weeks":
-		*t = Weeks
-	case "months":
-		*t = Months
-	}
-
-	return nil
-}
-
-// Marshaler for json
-func (t TimeUnit) MarshalJSON() ([]byte, error) {
-	var s string
-	switch t {
-	case Minutes:
-		s = "minutes"
-	case Hours:
-		s = "hours"
-	case Days:
-		s = "days"
-	case Weeks:
-		s = "weeks"
-	case Months:
-		s = "months"
-	}
-
-	return json.Marshal(s)
-}
-
 type Schedule struct {
 	Units    int      `json:"units,omitempty"`
 	TimeUnit TimeUnit `json:"timeUnit"`
 }
 
-type ConnectionStatus int
+// The status of a connection
+type ConnectionStatus string
 
 const (
-	Active ConnectionStatus = iota + 1
-	Inactive
-	Deprecated
+	Active     ConnectionStatus = "active"
+	Inactive   ConnectionStatus = "inactive"
+	Deprecated ConnectionStatus = "deprecated"
 )
 
-// Unmarshaler for json
-func (c *ConnectionStatus) UnmarshalJSON(b []byte) error {
-	var s string
-	if err := json.Unmarshal(b, &s); err != nil {
-		return err
-	}
-
-	switch strings.ToLower(s) {
-	case "active":
-		*c = Active
-	case "inactive":
-		*c = Inactive
-	case "deprecated":
-		*c = Deprecated
-	}
-
-	return nil
-}
-
-// Marshaler for json
-func (c ConnectionStatus) MarshalJSON() ([]byte, error) {
-	var s string
-	switch c {
-	case Active:
-		s = "active"
-	case Inactive:
-		s = "inactive"
-	case Deprecated:
-		s = "deprecated"
-	}
-
-	return json.Marshal(s)
-}
-
 type ResourceRequirements struct {
 	CpuRequest    string `json:"cpu_request,omitempty"`
 	CpuLimit      string `json:"cpu_limit,omitempty"`
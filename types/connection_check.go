@@ -3,46 +3,16 @@ package types
 import (
 	"encoding/json"
 	"io"
-	"strings"
 )
 
-type StatusType int
+// The status of a connection check
+type StatusType string
 
 const (
-	Succeeded StatusType = iota + 1
-	Failed
+	Succeeded StatusType = "succeeded"
+	Failed    StatusType = "failed"
 )
 
-// Unmarshaler for json
-func (st *StatusType) UnmarshalJSON(b []byte) error {
-	var s string
-	if err := json.Unmarshal(b, &s); err != nil {
-		return err
-	}
-
-	switch strings.ToLower(s) {
-	case "succeeded":
-		*st = Succeeded
-	case "failed":
-		*st = Failed
-	}
-
-	return nil
-}
-
-// Marshaler for json
-func (st StatusType) MarshalJSON() ([]byte, error) {
-	var s string
-	switch st {
-	case Succeeded:
-		s = "succeeded"
-	case Failed:
-		s = "failed"
-	}
-
-	return json.Marshal(s)
-}
-
 type ConnectionCheck struct {
 	Status  StatusType `json:"status,omitempty"`
 	Message string     `json:"message,omitempty"`
@@ -0,0 +1,87 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestSyncCatalogRoundTrip(t *testing.T) {
+	original, err := os.ReadFile("testdata/catalog.json")
+	if err != nil {
+		t.Fatalf("could not read catalog: %v", err)
+	}
+
+	catalog := new(SyncCatalogType)
+	if err := json.NewDecoder(bytes.NewReader(original)).Decode(catalog); err != nil {
+		t.Fatalf("could not decode catalog: %v", err)
+	}
+
+	users := catalog.Streams[1]
+	if len(users.Stream.SupportedSyncModes) != 3 || users.Stream.SupportedSyncModes[1] != Incremental {
+		t.Fatalf("unexpected supported sync modes: %v", users.Stream.SupportedSyncModes)
+	}
+
+	if users.Stream.SupportedSyncModes[2] != "resumable_full_refresh" {
+		t.Fatal("unknown sync mode was not preserved")
+	}
+
+	if users.Config.DestinationSyncMode != "overwrite_dedup" {
+		t.Fatal("unknown destination sync mode was not preserved")
+	}
+
+	encoded, err := json.Marshal(catalog)
+	if err != nil {
+		t.Fatalf("could not encode catalog: %v", err)
+	}
+
+	var want, got interface{}
+	if err := json.Unmarshal(original, &want); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := json.Unmarshal(encoded, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	// Airbyte treats null and missing fields the same way
+	if !reflect.DeepEqual(withoutNulls(want), withoutNulls(got)) {
+		t.Fatalf("round trip changed the catalog:\nwant %s\ngot  %s", original, encoded)
+	}
+}
+
+func TestDestinationDefinitionSpecificationSyncModes(t *testing.T) {
+	data := `{"supportedDestinationSyncModes":["overwrite","append","append_dedup"],"supportsDbt":true}`
+
+	spec, err := DestinationDefinitionSpecificationToJSON(bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatalf("could not decode specification: %v", err)
+	}
+
+	want := []SupportedDestinationSyncModesType{Overwrite, Append, AppendDedup}
+	if !reflect.DeepEqual(spec.SupportedDestinationSyncModes, want) {
+		t.Fatalf("unexpected sync modes: %v", spec.SupportedDestinationSyncModes)
+	}
+}
+
+// Removes the keys with null values from all the objects in v
+func withoutNulls(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for k, field := range value {
+			if field == nil {
+				delete(value, k)
+				continue
+			}
+			value[k] = withoutNulls(field)
+		}
+	case []interface{}:
+		for i := range value {
+			value[i] = withoutNulls(value[i])
+		}
+	}
+
+	return v
+}
@@ -1,57 +1,15 @@
 package types
 
-import (
-	"encoding/json"
-	"strings"
-)
-
-type ReleaseStage int
+// The release stage of a connector definition
+type ReleaseStage string
 
 const (
-	Alpha ReleaseStage = iota + 1
-	Beta
-	GenerallyAvailable
-	Custom
+	Alpha              ReleaseStage = "alpha"
+	Beta               ReleaseStage = "beta"
+	GenerallyAvailable ReleaseStage = "generally_available"
+	Custom             ReleaseStage = "custom"
 )
 
-// Unmarshaler for json
-func (r *ReleaseStage) UnmarshalJSON(b []byte) error {
-	var s string
-	if err := json.Unmarshal(b, &s); err != nil {
-		return err
-	}
-
-	switch strings.ToLower(s) {
-	case "alpha":
-		*r = Alpha
-	case "beta":
-		*r = Beta
-	case "generally_available":
-		*r = GenerallyAvailable
-	case "custom":
-		*r = Custom
-	}
-
-	return nil
-}
-
-// Marshaler for json
-func (r ReleaseStage) MarshalJSON() ([]byte, error) {
-	var s string
-	switch r {
-	case Alpha:
-		s = "alpha"
-	case Beta:
-		s = "beta"
-	case GenerallyAvailable:
-		s = "generally_available"
-	case Custom:
-		s = "custom"
-	}
-
-	return json.Marshal(s)
-}
-
 type Definition struct {
 	Name             string       `json:"name,omitempty"`
 	DockerRepository string       `json:"dockerRepository,omitempty"`
@@ -62,38 +20,13 @@ type Definition struct {
 	ReleaseDate      string       `json:"releaseDate,omitempty"`
 }
 
-type AuthenticationType int
+// The authentication type of a connector specification
+type AuthenticationType string
 
 const (
-	OAuth AuthenticationType = iota + 1
+	OAuth AuthenticationType = "oauth2.0"
 )
 
-// Unmarshaler for json
-func (a *AuthenticationType) UnmarshalJSON(b []byte) error {
-	var s string
-	if err := json.Unmarshal(b, &s); err != nil {
-		return err
-	}
-
-	switch strings.ToLower(s) {
-	case "oath2.0":
-		*a = OAuth
-	}
-
-	return nil
-}
-
-// Marshaler for json
-func (a AuthenticationType) MarshalJSON() ([]byte, error) {
-	var s string
-	switch a {
-	case OAuth:
-		s = "oath2.0"
-	}
-
-	return json.Marshal(s)
-}
-
 type Oauth2Specification struct {
 	RootObject                interface{} `json:"rootObject,omitempty"`
 	OauthFlowInitParameters   [][]string  `json:"oauthFlowInitParameters,omitempty"`
@@ -105,43 +38,14 @@ type AuthSpecification struct {
 	Oauth2Specification *Oauth2Specification `json:"oauth2Specification,omitempty"`
 }
 
-type AuthFlowType int
+// The OAuth flow type of a connector specification
+type AuthFlowType string
 
 const (
-	OAuth2 AuthFlowType = iota + 1
-	OAuth1
+	OAuth2 AuthFlowType = "oauth2.0"
+	OAuth1 AuthFlowType = "oauth1.0"
 )
 
-// Unmarshaler for json
-func (a *AuthFlowType) UnmarshalJSON(b []byte) error {
-	var s string
-	if err := json.Unmarshal(b, &s); err != nil {
-		return err
-	}
-
-	switch strings.ToLower(s) {
-	case "oath2.0":
-		*a = OAuth2
-	case "oath1.0":
-		*a = OAuth1
-	}
-
-	return nil
-}
-
-// Marshaler for json
-func (a AuthFlowType) MarshalJSON() ([]byte, error) {
-	var s string
-	switch a {
-	case OAuth2:
-		s = "oath2.0"
-	case OAuth1:
-		s = "oath1.0"
-	}
-
-	return json.Marshal(s)
-}
-
 type OauthConfigSpecification struct {
 	OauthUserInputFromConnectorConfigSpecification []byte `json:"oauthUserInputFromConnectorConfigSpecification,omitempty"`
 	CompleteOAuthOutputSpecification               []byte `json:"completeOAuthOutputSpecification,omitempty"`
@@ -3,7 +3,6 @@ package types
 import (
 	"encoding/json"
 	"io"
-	"strings"
 
 	"github.com/google/uuid"
 )
@@ -13,54 +12,21 @@ type DestinationDefinition struct {
 	DestinationDefinitionId *uuid.UUID `json:"destinationDefinitionId,omitempty"`
 }
 
-type SupportedDestinationSyncModesType int
+// The sync mode of a destination
+type SupportedDestinationSyncModesType string
 
 const (
-	Append SupportedDestinationSyncModesType = iota + 1
-	Overwrite
-	AppendDedup
+	Append      SupportedDestinationSyncModesType = "append"
+	Overwrite   SupportedDestinationSyncModesType = "overwrite"
+	AppendDedup SupportedDestinationSyncModesType = "append_dedup"
 )
 
-// Unmarshaler for json
-func (sup *SupportedDestinationSyncModesType) UnmarshalJSON(b []byte) error {
-	var s string
-	if err := json.Unmarshal(b, &s); err != nil {
-		return err
-	}
-
-	switch strings.ToLower(s) {
-	case "append":
-		*sup = Append
-	case "overwrite":
-		*sup = Overwrite
-	case "append_dedup":
-		*sup = AppendDedup
-	}
-
-	return nil
-}
-
-// Marshaler for json
-func (sup SupportedDestinationSyncModesType) MarshalJSON() ([]byte, error) {
-	var s string
-	switch sup {
-	case Append:
-		s = "append"
-	case Overwrite:
-		s = "overwrite"
-	case AppendDedup:
-		s = "append_dedup"
-	}
-
-	return json.Marshal(s)
-}
-
 type DestinationDefinitionSpecification struct {
 	DefinitionSpecification
-	DestinationDefinitionId       *uuid.UUID                        `json:"destinationDefinitionId,omitempty"`
-	SupportedDestinationSyncModes SupportedDestinationSyncModesType `json:"supportedDestinationSyncModes,omitempty"`
-	SupportsDbt                   bool                              `json:"supportsDbt,omitempty"`
-	SupportsNormalization         bool                              `json:"supportsNormalization,omitempty"`
+	DestinationDefinitionId       *uuid.UUID                          `json:"destinationDefinitionId,omitempty"`
+	SupportedDestinationSyncModes []SupportedDestinationSyncModesType `json:"supportedDestinationSyncModes,omitempty"`
+	SupportsDbt                   bool                                `json:"supportsDbt,omitempty"`
+	SupportsNormalization         bool                                `json:"supportsNormalization,omitempty"`
 }
 
 // DestinationDefinitionFromJSON reads json data from a Reader and returns a destination definition
@@ -0,0 +1,7 @@
+// Package types contains the models of the airbyte API.
+//
+// Enumerations are string types holding the raw value sent by the server.
+// The declared constants cover the values known to the SDK, while values
+// introduced by newer airbyte versions are kept as is, so decoding and
+// re-encoding a document does not lose them.
+package types
@@ -0,0 +1,122 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidVersion = errors.New("invalid version")
+
+// The health of the airbyte server
+type HealthCheck struct {
+	Available bool `json:"available"`
+}
+
+// Information about the airbyte deployment
+type DeploymentMetadata struct {
+	ID            *uuid.UUID `json:"id,omitempty"`
+	Mode          string     `json:"mode,omitempty"`
+	Version       string     `json:"version,omitempty"`
+	DeploymentEnv string     `json:"deploymentEnv,omitempty"`
+}
+
+// A semantic version of the airbyte server, such as 0.40.18
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+	// The pre-release part of the version, such as "alpha" in 0.40.0-alpha
+	PreRelease string
+}
+
+// ParseVersion parses a version of the form major.minor.patch with an optional
+// "v" prefix and pre-release suffix. Versions of development builds, such as "dev", are invalid
+func ParseVersion(s string) (Version, error) {
+	var v Version
+	core := strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexAny(core, "-+"); i >= 0 {
+		if core[i] == '-' {
+			v.PreRelease = strings.SplitN(core[i+1:], "+", 2)[0]
+		}
+		core = core[:i]
+	}
+
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return Version{}, fmt.Errorf("%w: %q", ErrInvalidVersion, s)
+	}
+
+	numbers := []*int{&v.Major, &v.Minor, &v.Patch}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return Version{}, fmt.Errorf("%w: %q", ErrInvalidVersion, s)
+		}
+		*numbers[i] = n
+	}
+
+	return v, nil
+}
+
+// Compare returns -1, 0 or 1 if the version is lower, equal or greater than the other.
+// A pre-release is lower than the release with the same number
+func (v Version) Compare(other Version) int {
+	for _, d := range []int{v.Major - other.Major, v.Minor - other.Minor, v.Patch - other.Patch} {
+		if d < 0 {
+			return -1
+		}
+
+		if d > 0 {
+			return 1
+		}
+	}
+
+	switch {
+	case v.PreRelease == other.PreRelease:
+		return 0
+	case v.PreRelease == "":
+		return 1
+	case other.PreRelease == "":
+		return -1
+	case v.PreRelease < other.PreRelease:
+		return -1
+	}
+
+	return 1
+}
+
+// AtLeast returns true if the version is greater than or equal to the given release
+func (v Version) AtLeast(major, minor, patch int) bool {
+	return v.Compare(Version{Major: major, Minor: minor, Patch: patch}) >= 0
+}
+
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.PreRelease != "" {
+		s += "-" + v.PreRelease
+	}
+
+	return s
+}
+
+// HealthCheckFromJSON reads json data from a Reader and returns a health check
+func HealthCheckFromJSON(r io.Reader) (*HealthCheck, error) {
+	health := new(HealthCheck)
+	err := json.NewDecoder(r).Decode(health)
+
+	return health, err
+}
+
+// DeploymentMetadataFromJSON reads json data from a Reader and returns the deployment metadata
+func DeploymentMetadataFromJSON(r io.Reader) (*DeploymentMetadata, error) {
+	metadata := new(DeploymentMetadata)
+	err := json.NewDecoder(r).Decode(metadata)
+
+	return metadata, err
+}
@@ -0,0 +1,46 @@
+package types
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Version
+	}{
+		{"0.40.18", Version{Major: 0, Minor: 40, Patch: 18}},
+		{"v1.2.3", Version{Major: 1, Minor: 2, Patch: 3}},
+		{"0.39.0-alpha+build.5", Version{Major: 0, Minor: 39, PreRelease: "alpha"}},
+	}
+
+	for _, test := range tests {
+		version, err := ParseVersion(test.input)
+		if err != nil {
+			t.Fatalf("could not parse %q: %v", test.input, err)
+		}
+
+		if version != test.expected {
+			t.Fatalf("%q: expected %+v, got %+v", test.input, test.expected, version)
+		}
+	}
+
+	for _, input := range []string{"dev", "1.2", "1.x.3", ""} {
+		if _, err := ParseVersion(input); !errors.Is(err, ErrInvalidVersion) {
+			t.Fatalf("%q: expected invalid version error, got %v", input, err)
+		}
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	ordered := []string{"0.39.9", "0.40.0-alpha", "0.40.0-beta", "0.40.0", "0.40.1", "1.0.0"}
+	for i := 1; i < len(ordered); i++ {
+		lower, _ := ParseVersion(ordered[i-1])
+		higher, _ := ParseVersion(ordered[i])
+
+		if lower.Compare(higher) != -1 || higher.Compare(lower) != 1 || higher.Compare(higher) != 0 {
+			t.Fatalf("expected %s < %s", lower, higher)
+		}
+	}
+}
@@ -2,7 +2,7 @@ package types
 
 import (
 	"encoding/json"
-	"strings"
+	"io"
 
 	"github.com/google/uuid"
 )
@@ -11,63 +11,18 @@ type Logs struct {
 	LogLines []string `json:"logLines"`
 }
 
-type ConfigTypeEnum int
+// The type of a job
+type ConfigTypeEnum string
 
 const (
-	CheckConnectionSource ConfigTypeEnum = iota + 1
-	CheckConnectionDestination
-	DiscoverSchema
-	GetSpec
-	Sync
-	ResetConnection
+	CheckConnectionSource      ConfigTypeEnum = "check_connection_source"
+	CheckConnectionDestination ConfigTypeEnum = "check_connection_destination"
+	DiscoverSchema             ConfigTypeEnum = "discover_schema"
+	GetSpec                    ConfigTypeEnum = "get_spec"
+	Sync                       ConfigTypeEnum = "sync"
+	ResetConnection            ConfigTypeEnum = "reset_connection"
 )
 
-// Unmarshaler for json
-func (a *ConfigTypeEnum) UnmarshalJSON(b []byte) error {
-	var s string
-	if err := json.Unmarshal(b, &s); err != nil {
-		return err
-	}
-
-	switch strings.ToLower(s) {
-	case "check_connection_source":
-		*a = CheckConnectionSource
-	case "check_connection_destination":
-		*a = CheckConnectionDestination
-	case "discover_schema":
-		*a = DiscoverSchema
-	case "get_spec":
-		*a = GetSpec
-	case "sync":
-		*a = Sync
-	case "reset_connection":
-		*a = ResetConnection
-	}
-
-	return nil
-}
-
-// Marshaler for json
-func (a ConfigTypeEnum) MarshalJSON() ([]byte, error) {
-	var s string
-	switch a {
-	case CheckConnectionSource:
-		s = "check_connection_source"
-	case CheckConnectionDestination:
-		s = "check_connection_destination"
-	case DiscoverSchema:
-		s = "discover_schema"
-	case GetSpec:
-		s = "get_spec"
-	case Sync:
-		s = "sync"
-	case ResetConnection:
-		s = "reset_connection"
-	}
-
-	return json.Marshal(s)
-}
-
 type JobInfo struct {
 	ID         *uuid.UUID     `json:"id,omitempty"`
 	ConfigType ConfigTypeEnum `json:"configType,omitempty"`
@@ -77,3 +32,115 @@ type JobInfo struct {
 	Succeeded  bool           `json:"succeeded,omitempty"`
 	Logs       *Logs          `json:"logLines,omitempty"`
 }
+
+// The status of a job
+type JobStatus string
+
+const (
+	JobPending    JobStatus = "pending"
+	JobRunning    JobStatus = "running"
+	JobIncomplete JobStatus = "incomplete"
+	JobFailed     JobStatus = "failed"
+	JobSucceeded  JobStatus = "succeeded"
+	JobCancelled  JobStatus = "cancelled"
+)
+
+// The status of a job attempt
+type AttemptStatus string
+
+const (
+	AttemptRunning   AttemptStatus = "running"
+	AttemptFailed    AttemptStatus = "failed"
+	AttemptSucceeded AttemptStatus = "succeeded"
+)
+
+// An asynchronous job, such as a sync or a reset of a connection
+type Job struct {
+	ID         int64          `json:"id"`
+	ConfigType ConfigTypeEnum `json:"configType,omitempty"`
+	ConfigId   string         `json:"configId,omitempty"`
+	CreatedAt  int64          `json:"createdAt,omitempty"`
+	UpdatedAt  int64          `json:"updatedAt,omitempty"`
+	Status     JobStatus      `json:"status,omitempty"`
+}
+
+// The reason an attempt failed
+type AttemptFailureReason struct {
+	FailureOrigin   string `json:"failureOrigin,omitempty"`
+	FailureType     string `json:"failureType,omitempty"`
+	ExternalMessage string `json:"externalMessage,omitempty"`
+	InternalMessage string `json:"internalMessage,omitempty"`
+	Stacktrace      string `json:"stacktrace,omitempty"`
+	Retryable       bool   `json:"retryable,omitempty"`
+	Timestamp       int64  `json:"timestamp,omitempty"`
+}
+
+// A summary of the failures of an attempt
+type AttemptFailureSummary struct {
+	Failures       []AttemptFailureReason `json:"failures,omitempty"`
+	PartialSuccess bool                   `json:"partialSuccess,omitempty"`
+}
+
+type AttemptStats struct {
+	RecordsEmitted       int64 `json:"recordsEmitted,omitempty"`
+	BytesEmitted         int64 `json:"bytesEmitted,omitempty"`
+	StateMessagesEmitted int64 `json:"stateMessagesEmitted,omitempty"`
+	RecordsCommitted     int64 `json:"recordsCommitted,omitempty"`
+}
+
+type AttemptStreamStats struct {
+	StreamName      string        `json:"streamName,omitempty"`
+	StreamNamespace string        `json:"streamNamespace,omitempty"`
+	Stats           *AttemptStats `json:"stats,omitempty"`
+}
+
+// A single attempt of a job
+type Attempt struct {
+	ID             int64                  `json:"id"`
+	Status         AttemptStatus          `json:"status,omitempty"`
+	CreatedAt      int64                  `json:"createdAt,omitempty"`
+	UpdatedAt      int64                  `json:"updatedAt,omitempty"`
+	EndedAt        int64                  `json:"endedAt,omitempty"`
+	BytesSynced    int64                  `json:"bytesSynced,omitempty"`
+	RecordsSynced  int64                  `json:"recordsSynced,omitempty"`
+	TotalStats     *AttemptStats          `json:"totalStats,omitempty"`
+	StreamStats    []AttemptStreamStats   `json:"streamStats,omitempty"`
+	FailureSummary *AttemptFailureSummary `json:"failureSummary,omitempty"`
+}
+
+// An attempt together with its logs
+type AttemptInfo struct {
+	Attempt *Attempt `json:"attempt,omitempty"`
+	Logs    *Logs    `json:"logs,omitempty"`
+}
+
+// A job with all of its attempts and their logs
+type JobDetails struct {
+	Job      *Job          `json:"job,omitempty"`
+	Attempts []AttemptInfo `json:"attempts,omitempty"`
+}
+
+// A job with all of its attempts, as returned when listing jobs
+type JobWithAttempts struct {
+	Job      *Job      `json:"job,omitempty"`
+	Attempts []Attempt `json:"attempts,omitempty"`
+}
+
+// JobDetailsFromJSON reads json data from a Reader and returns the job details
+func JobDetailsFromJSON(r io.Reader) (*JobDetails, error) {
+	details := new(JobDetails)
+	err := json.NewDecoder(r).Decode(details)
+
+	return details, err
+}
+
+// JobsFromJSON reads json data from a Reader and returns a slice of jobs with their attempts
+func JobsFromJSON(r io.Reader) ([]JobWithAttempts, error) {
+	var jobs struct {
+		Jobs []JobWithAttempts `json:"jobs"`
+	}
+
+	// Decode JSON
+	err := json.NewDecoder(r).Decode(&jobs)
+	return jobs.Jobs, err
+}
@@ -0,0 +1,84 @@
+package types
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/google/uuid"
+)
+
+// The type of an operator that runs after a sync
+type OperatorType string
+
+const (
+	Normalization OperatorType = "normalization"
+	Dbt           OperatorType = "dbt"
+	Webhook       OperatorType = "webhook"
+)
+
+// The kind of normalization an operator applies
+type NormalizationOption string
+
+const (
+	BasicNormalization NormalizationOption = "basic"
+)
+
+// Configuration options for normalization operators
+type OperatorNormalization struct {
+	Option NormalizationOption `json:"option,omitempty"`
+}
+
+// Configuration options for operators running a custom dbt transformation
+type OperatorDbt struct {
+	GitRepoUrl    string `json:"gitRepoUrl"`
+	GitRepoBranch string `json:"gitRepoBranch,omitempty"`
+	DockerImage   string `json:"dockerImage,omitempty"`
+	DbtArguments  string `json:"dbtArguments,omitempty"`
+}
+
+type OperatorConfiguration struct {
+	OperatorType  OperatorType           `json:"operatorType"`
+	Normalization *OperatorNormalization `json:"normalization,omitempty"`
+	Dbt           *OperatorDbt           `json:"dbt,omitempty"`
+}
+
+// An operation that runs after the syncs of the connections it is attached to
+type Operation struct {
+	OperationId           *uuid.UUID             `json:"operationId,omitempty"`
+	WorkspaceId           *uuid.UUID             `json:"workspaceId,omitempty"`
+	Name                  string                 `json:"name,omitempty"`
+	OperatorConfiguration *OperatorConfiguration `json:"operatorConfiguration,omitempty"`
+}
+
+// The result of checking an operator configuration
+type OperationCheck struct {
+	Status  StatusType `json:"status,omitempty"`
+	Message string     `json:"message,omitempty"`
+}
+
+// OperationFromJSON reads json data from a Reader and returns an operation
+func OperationFromJSON(r io.Reader) (*Operation, error) {
+	operation := new(Operation)
+	err := json.NewDecoder(r).Decode(operation)
+
+	return operation, err
+}
+
+// OperationsFromJSON reads json data from a Reader and returns a slice of operations
+func OperationsFromJSON(r io.Reader) ([]Operation, error) {
+	var operations struct {
+		Operations []Operation `json:"operations"`
+	}
+
+	// Decode JSON
+	err := json.NewDecoder(r).Decode(&operations)
+	return operations.Operations, err
+}
+
+// OperationCheckFromJSON reads json data from a Reader and returns an operation check
+func OperationCheckFromJSON(r io.Reader) (*OperationCheck, error) {
+	check := new(OperationCheck)
+	err := json.NewDecoder(r).Decode(check)
+
+	return check, err
+}
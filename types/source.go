@@ -34,3 +34,18 @@ func SourcesFromJSON(r io.Reader) ([]Source, error) {
 	err := json.NewDecoder(r).Decode(&sources)
 	return sources.Sources, err
 }
+
+// The result of a source schema discovery
+type SourceDiscoverSchema struct {
+	Catalog   *SyncCatalogType `json:"catalog,omitempty"`
+	JobInfo   *JobInfo         `json:"jobInfo,omitempty"`
+	CatalogId *uuid.UUID       `json:"catalogId,omitempty"`
+}
+
+// SourceDiscoverSchemaFromJSON reads json data from a Reader and returns a source schema discovery result
+func SourceDiscoverSchemaFromJSON(r io.Reader) (*SourceDiscoverSchema, error) {
+	discovered := new(SourceDiscoverSchema)
+	err := json.NewDecoder(r).Decode(discovered)
+
+	return discovered, err
+}
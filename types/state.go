@@ -0,0 +1,74 @@
+package types
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/google/uuid"
+)
+
+// The type of the state of a connection
+type StateType string
+
+const (
+	LegacyStateType StateType = "legacy"
+	GlobalStateType StateType = "global"
+	StreamStateType StateType = "stream"
+	NotSetStateType StateType = "not_set"
+)
+
+// Identifies a stream by its name and namespace
+type StreamDescriptor struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// The state of a single stream, such as the position of its incremental cursor
+type StreamState struct {
+	StreamDescriptor *StreamDescriptor      `json:"streamDescriptor,omitempty"`
+	StreamState      map[string]interface{} `json:"streamState,omitempty"`
+}
+
+// State shared by all the streams of a source along with the state of each stream
+type GlobalState struct {
+	SharedState  map[string]interface{} `json:"shared_state,omitempty"`
+	StreamStates []StreamState          `json:"streamStates,omitempty"`
+}
+
+// The state of a connection. Depending on its type, either State, StreamState or GlobalState is set
+type ConnectionState struct {
+	StateType    StateType              `json:"stateType,omitempty"`
+	ConnectionId *uuid.UUID             `json:"connectionId,omitempty"`
+	State        map[string]interface{} `json:"state,omitempty"`
+	StreamState  []StreamState          `json:"streamState,omitempty"`
+	GlobalState  *GlobalState           `json:"globalState,omitempty"`
+}
+
+// FindStream returns the state of the stream with the given name and namespace or nil if it does not exist.
+// Legacy states do not keep the state of each stream separately, so nil is always returned for them
+func (cs *ConnectionState) FindStream(name, namespace string) *StreamState {
+	entries := cs.StreamState
+	if cs.StateType == GlobalStateType && cs.GlobalState != nil {
+		entries = cs.GlobalState.StreamStates
+	}
+
+	for i := range entries {
+		desc := entries[i].StreamDescriptor
+		if desc != nil && desc.Name == name && desc.Namespace == namespace {
+			return &entries[i]
+		}
+	}
+
+	return nil
+}
+
+// ConnectionStateFromJSON reads json data from a Reader and returns a connection state.
+// Numbers are decoded as json.Number so cursor values keep their precision
+func ConnectionStateFromJSON(r io.Reader) (*ConnectionState, error) {
+	state := new(ConnectionState)
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	err := decoder.Decode(state)
+
+	return state, err
+}
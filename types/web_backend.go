@@ -0,0 +1,105 @@
+package types
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/google/uuid"
+)
+
+// The kind of change made to a stream of a catalog
+type StreamTransformType string
+
+const (
+	AddStream    StreamTransformType = "add_stream"
+	RemoveStream StreamTransformType = "remove_stream"
+	UpdateStream StreamTransformType = "update_stream"
+)
+
+// The kind of change made to a field of a stream
+type FieldTransformType string
+
+const (
+	AddField          FieldTransformType = "add_field"
+	RemoveField       FieldTransformType = "remove_field"
+	UpdateFieldSchema FieldTransformType = "update_field_schema"
+)
+
+type FieldSchema struct {
+	Schema map[string]interface{} `json:"schema,omitempty"`
+}
+
+type FieldSchemaUpdate struct {
+	OldSchema map[string]interface{} `json:"oldSchema,omitempty"`
+	NewSchema map[string]interface{} `json:"newSchema,omitempty"`
+}
+
+// A change to a field of a stream
+type FieldTransform struct {
+	TransformType     FieldTransformType `json:"transformType"`
+	FieldName         []string           `json:"fieldName"`
+	Breaking          bool               `json:"breaking"`
+	AddField          *FieldSchema       `json:"addField,omitempty"`
+	RemoveField       *FieldSchema       `json:"removeField,omitempty"`
+	UpdateFieldSchema *FieldSchemaUpdate `json:"updateFieldSchema,omitempty"`
+}
+
+// A change to a stream of a catalog
+type StreamTransform struct {
+	TransformType    StreamTransformType `json:"transformType"`
+	StreamDescriptor *StreamDescriptor   `json:"streamDescriptor,omitempty"`
+	UpdateStream     []FieldTransform    `json:"updateStream,omitempty"`
+}
+
+// The changes between the catalog of a connection and the latest catalog of its source, as computed by the server
+type CatalogDiff struct {
+	Transforms []StreamTransform `json:"transforms"`
+}
+
+// A connection together with its source, destination, operations and the status of its latest sync
+type WebBackendConnection struct {
+	Connection
+	Source                 *Source      `json:"source,omitempty"`
+	Destination            *Destination `json:"destination,omitempty"`
+	Operations             []Operation  `json:"operations,omitempty"`
+	LatestSyncJobCreatedAt int64        `json:"latestSyncJobCreatedAt,omitempty"`
+	LatestSyncJobStatus    JobStatus    `json:"latestSyncJobStatus,omitempty"`
+	IsSyncing              bool         `json:"isSyncing,omitempty"`
+	CatalogId              *uuid.UUID   `json:"catalogId,omitempty"`
+	CatalogDiff            *CatalogDiff `json:"catalogDiff,omitempty"`
+}
+
+// A new connection to create along with the operations to create for it
+type WebBackendConnectionCreate struct {
+	Connection
+	Operations      []Operation `json:"operations,omitempty"`
+	SourceCatalogId *uuid.UUID  `json:"sourceCatalogId,omitempty"`
+}
+
+// An update of a connection along with the operations to create or update for it
+type WebBackendConnectionUpdate struct {
+	Connection
+	Operations      []Operation `json:"operations,omitempty"`
+	SourceCatalogId *uuid.UUID  `json:"sourceCatalogId,omitempty"`
+	// Do not reset the streams whose configuration changed
+	SkipReset bool `json:"skipReset,omitempty"`
+}
+
+// WebBackendConnectionFromJSON reads json data from a Reader and returns a web backend connection
+func WebBackendConnectionFromJSON(r io.Reader) (*WebBackendConnection, error) {
+	conn := new(WebBackendConnection)
+	err := json.NewDecoder(r).Decode(conn)
+
+	return conn, err
+}
+
+// WebBackendConnectionsFromJSON reads json data from a Reader and returns a slice of web backend connections
+func WebBackendConnectionsFromJSON(r io.Reader) ([]WebBackendConnection, error) {
+	var connections struct {
+		Connections []WebBackendConnection `json:"connections"`
+	}
+
+	// Decode JSON
+	err := json.NewDecoder(r).Decode(&connections)
+	return connections.Connections, err
+}
@@ -3,44 +3,17 @@ package types
 import (
 	"encoding/json"
 	"io"
-	"strings"
 
 	"github.com/google/uuid"
 )
 
 // All the possible notification types
-type NotificationType int
+type NotificationType string
 
 const (
-	Slack NotificationType = iota + 1
+	Slack NotificationType = "slack"
 )
 
-// Unmarshaler for json
-func (n *NotificationType) UnmarshalJSON(b []byte) error {
-	var s string
-	if err := json.Unmarshal(b, &s); err != nil {
-		return err
-	}
-
-	switch strings.ToLower(s) {
-	case "slack":
-		*n = Slack
-	}
-
-	return nil
-}
-
-// Marshaler for json
-func (n NotificationType) MarshalJSON() ([]byte, error) {
-	var s string
-	switch n {
-	case Slack:
-		s = "slack"
-	}
-
-	return json.Marshal(s)
-}
-
 // Configuration options for slack notification
 type SlackConfiguration struct {
 	Webhook string `json:"webhook"`
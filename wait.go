@@ -0,0 +1,140 @@
+package airbytesdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/evris99/airbyte-sdk/types"
+)
+
+var ErrWaitTimeout = errors.New("timed out waiting for job")
+
+const defaultPollInterval = 5 * time.Second
+
+// Options for waiting on a job
+type WaitOptions struct {
+	// The initial interval between polls. Defaults to 5 seconds
+	PollInterval time.Duration
+	// The factor the poll interval is multiplied by after each poll.
+	// Values less than or equal to 1 disable backoff
+	BackoffMultiplier float64
+	// The upper limit of the poll interval when backing off
+	MaxPollInterval time.Duration
+	// The maximum total time to wait for the job. Zero means no limit
+	MaxDuration time.Duration
+}
+
+// The result of a job that reached a terminal state
+type JobResult struct {
+	// The last fetched state of the job
+	Job *types.JobDetails
+	// The terminal status of the job
+	Status types.JobStatus
+	// The failure summary of the last attempt, if any
+	FailureSummary *types.AttemptFailureSummary
+}
+
+// Succeeded returns true if the job succeeded
+func (r *JobResult) Succeeded() bool {
+	return r.Status == types.JobSucceeded
+}
+
+// WaitForJob polls the job with the given ID until it succeeds, fails, is cancelled or is incomplete.
+// If opts is nil the default options are used
+func (c *Client) WaitForJob(ctx context.Context, id int64, opts *WaitOptions) (*JobResult, error) {
+	if opts == nil {
+		opts = &WaitOptions{}
+	}
+
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	// The maximum duration also limits the polls, so a hanging request does not exceed it
+	waitCtx, cancel := withMaxDuration(ctx, opts.MaxDuration)
+	defer cancel()
+
+	for {
+		details, err := c.GetJob(waitCtx, id)
+		if err != nil {
+			if waitTimedOut(ctx, waitCtx) {
+				return nil, fmt.Errorf("%w: %v", ErrWaitTimeout, err)
+			}
+
+			return nil, err
+		}
+
+		if details.Job != nil && isTerminalJobStatus(details.Job.Status) {
+			return newJobResult(details), nil
+		}
+
+		wait := time.NewTimer(interval)
+		select {
+		case <-waitCtx.Done():
+			wait.Stop()
+			if waitTimedOut(ctx, waitCtx) {
+				return nil, ErrWaitTimeout
+			}
+
+			return nil, ctx.Err()
+		case <-wait.C:
+		}
+
+		interval = nextPollInterval(interval, opts)
+	}
+}
+
+// Returns a context that is cancelled after the maximum duration of a wait, if there is one
+func withMaxDuration(ctx context.Context, maxDuration time.Duration) (context.Context, context.CancelFunc) {
+	if maxDuration <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, maxDuration)
+}
+
+// Reports whether the wait context reached its maximum duration while the context of the caller is still active
+func waitTimedOut(ctx, waitCtx context.Context) bool {
+	return ctx.Err() == nil && errors.Is(waitCtx.Err(), context.DeadlineExceeded)
+}
+
+// Returns the poll interval that follows the given one according to the options
+func nextPollInterval(interval time.Duration, opts *WaitOptions) time.Duration {
+	if opts.BackoffMultiplier <= 1 {
+		return interval
+	}
+
+	next := time.Duration(float64(interval) * opts.BackoffMultiplier)
+	if opts.MaxPollInterval > 0 && next > opts.MaxPollInterval {
+		return opts.MaxPollInterval
+	}
+
+	return next
+}
+
+func isTerminalJobStatus(status types.JobStatus) bool {
+	switch status {
+	case types.JobSucceeded, types.JobFailed, types.JobCancelled, types.JobIncomplete:
+		return true
+	}
+
+	return false
+}
+
+func newJobResult(details *types.JobDetails) *JobResult {
+	result := &JobResult{
+		Job:    details,
+		Status: details.Job.Status,
+	}
+
+	if len(details.Attempts) > 0 {
+		if last := details.Attempts[len(details.Attempts)-1].Attempt; last != nil {
+			result.FailureSummary = last.FailureSummary
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,121 @@
+package airbytesdk
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/evris99/airbyte-sdk/types"
+)
+
+func TestWaitForJob(t *testing.T) {
+	polls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		if polls < 3 {
+			w.Write([]byte(`{"job":{"id":7,"status":"running"},"attempts":[{"attempt":{"id":0,"status":"running"}}]}`))
+			return
+		}
+
+		w.Write([]byte(`{"job":{"id":7,"status":"failed"},"attempts":[{"attempt":{"id":0,"status":"failed"}},{"attempt":{"id":1,"status":"failed","failureSummary":{"failures":[{"failureOrigin":"destination","externalMessage":"disk full"}]}}}]}`))
+	}))
+	defer srv.Close()
+
+	airbyte, err := New(srv.URL + "/api")
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	opts := &WaitOptions{
+		PollInterval:      time.Millisecond,
+		BackoffMultiplier: 2,
+		MaxPollInterval:   5 * time.Millisecond,
+	}
+
+	result, err := airbyte.WaitForJob(context.Background(), 7, opts)
+	if err != nil {
+		t.Fatalf("could not wait for job: %v", err)
+	}
+
+	if polls != 3 {
+		t.Fatalf("expected 3 polls, got %d", polls)
+	}
+
+	if result.Succeeded() || result.Status != types.JobFailed {
+		t.Fatalf("unexpected status %v", result.Status)
+	}
+
+	if result.FailureSummary == nil || result.FailureSummary.Failures[0].ExternalMessage != "disk full" {
+		t.Fatal("expected the failure summary of the last attempt")
+	}
+}
+
+func TestWaitForJobTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"job":{"id":7,"status":"pending"}}`))
+	}))
+	defer srv.Close()
+
+	airbyte, err := New(srv.URL + "/api")
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	opts := &WaitOptions{PollInterval: time.Millisecond, MaxDuration: 20 * time.Millisecond}
+	if _, err := airbyte.WaitForJob(context.Background(), 7, opts); !errors.Is(err, ErrWaitTimeout) {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := airbyte.WaitForJob(ctx, 7, opts); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context error, got %v", err)
+	}
+}
+
+func TestNextPollInterval(t *testing.T) {
+	opts := &WaitOptions{BackoffMultiplier: 3, MaxPollInterval: 10 * time.Second}
+
+	if next := nextPollInterval(2*time.Second, opts); next != 6*time.Second {
+		t.Fatalf("expected 6s, got %v", next)
+	}
+
+	if next := nextPollInterval(6*time.Second, opts); next != 10*time.Second {
+		t.Fatalf("expected the interval to be capped at 10s, got %v", next)
+	}
+
+	if next := nextPollInterval(time.Second, &WaitOptions{}); next != time.Second {
+		t.Fatalf("expected no backoff, got %v", next)
+	}
+}
+
+func TestWaitForJobHangingPoll(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Read the body, so the server notices when the client goes away
+		io.Copy(io.Discard, r.Body)
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	airbyte, err := New(srv.URL + "/api")
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	start := time.Now()
+	opts := &WaitOptions{PollInterval: time.Millisecond, MaxDuration: 50 * time.Millisecond}
+	if _, err := airbyte.WaitForJob(context.Background(), 7, opts); !errors.Is(err, ErrWaitTimeout) {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected the wait to stop after the maximum duration, took %v", elapsed)
+	}
+}
@@ -0,0 +1,81 @@
+package airbytesdk
+
+import (
+	"context"
+
+	"github.com/evris99/airbyte-sdk/types"
+	"github.com/google/uuid"
+)
+
+// GetWebBackendConnection returns the connection with the given ID along with its source, destination and latest sync status.
+// If withRefreshedCatalog is true the schema of the source is discovered again and the changes are returned in the catalog diff
+func (c *Client) GetWebBackendConnection(ctx context.Context, id *uuid.UUID, withRefreshedCatalog bool) (*types.WebBackendConnection, error) {
+	u, err := appendToURL(c.endpoint, "/v1/web_backend/connections/get")
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]interface{})
+	data["connectionId"] = id
+	data["withRefreshedCatalog"] = withRefreshedCatalog
+
+	res, err := c.makeRequest(ctx, u, data)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	return types.WebBackendConnectionFromJSON(res.Body)
+}
+
+// CreateWebBackendConnection creates a connection along with its operations
+func (c *Client) CreateWebBackendConnection(ctx context.Context, conn *types.WebBackendConnectionCreate) (*types.WebBackendConnection, error) {
+	u, err := appendToURL(c.endpoint, "/v1/web_backend/connections/create")
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.makeRequest(ctx, u, conn)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	return types.WebBackendConnectionFromJSON(res.Body)
+}
+
+// UpdateWebBackendConnection updates a connection along with its operations
+func (c *Client) UpdateWebBackendConnection(ctx context.Context, conn *types.WebBackendConnectionUpdate) (*types.WebBackendConnection, error) {
+	u, err := appendToURL(c.endpoint, "/v1/web_backend/connections/update")
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.makeRequest(ctx, u, conn)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	return types.WebBackendConnectionFromJSON(res.Body)
+}
+
+// ListWebBackendConnections returns the connections of the workspace with the given ID
+// along with their sources, destinations and latest sync status
+func (c *Client) ListWebBackendConnections(ctx context.Context, workspaceID *uuid.UUID) ([]types.WebBackendConnection, error) {
+	u, err := appendToURL(c.endpoint, "/v1/web_backend/connections/list")
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]*uuid.UUID)
+	data["workspaceId"] = workspaceID
+
+	res, err := c.makeRequest(ctx, u, data)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	return types.WebBackendConnectionsFromJSON(res.Body)
+}
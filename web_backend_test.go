@@ -0,0 +1,95 @@
+package airbytesdk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/evris99/airbyte-sdk/airbytetest"
+	"github.com/evris99/airbyte-sdk/types"
+)
+
+func TestWebBackendConnections(t *testing.T) {
+	srv := airbytetest.NewServer()
+	defer srv.Close()
+
+	airbyte, err := New(srv.Endpoint())
+	if err != nil {
+		t.Fatalf("could not create instance: %v", err)
+	}
+
+	ctx := context.Background()
+	existing := createTestConnection(t, airbyte)
+
+	create := &types.WebBackendConnectionCreate{
+		Connection: types.Connection{
+			Name:          "with operations",
+			SourceID:      existing.SourceID,
+			DestinationId: existing.DestinationId,
+			Status:        types.Active,
+		},
+		Operations: []types.Operation{{
+			Name: "normalization",
+			OperatorConfiguration: &types.OperatorConfiguration{
+				OperatorType:  types.Normalization,
+				Normalization: &types.OperatorNormalization{Option: types.BasicNormalization},
+			},
+		}},
+	}
+
+	conn, err := airbyte.CreateWebBackendConnection(ctx, create)
+	if err != nil {
+		t.Fatalf("could not create connection: %v", err)
+	}
+
+	if conn.Source == nil || conn.Destination == nil || len(conn.Operations) != 1 || len(conn.OperationIds) != 1 {
+		t.Fatalf("expected an enriched connection, got %+v", conn)
+	}
+
+	job, err := airbyte.SyncConnection(ctx, conn.ConnectionId)
+	if err != nil {
+		t.Fatalf("could not sync connection: %v", err)
+	}
+
+	connections, err := airbyte.ListWebBackendConnections(ctx, conn.Source.WorkspaceId)
+	if err != nil || len(connections) != 2 {
+		t.Fatalf("expected 2 connections, got %v and %v", connections, err)
+	}
+
+	if synced := connections[1]; synced.Name != "with operations" || !synced.IsSyncing || synced.LatestSyncJobStatus != types.JobRunning {
+		t.Fatalf("expected the latest sync job, got %+v", synced)
+	}
+
+	if err := srv.FinishJob(job.Job.ID, types.JobFailed, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	srv.SetCatalog(*conn.SourceID, &types.SyncCatalogType{
+		Streams: []types.StreamAndConfiguration{{Stream: &types.StreamType{Name: "pokemon"}}},
+	})
+
+	refreshed, err := airbyte.GetWebBackendConnection(ctx, conn.ConnectionId, true)
+	if err != nil {
+		t.Fatalf("could not get connection: %v", err)
+	}
+
+	if refreshed.IsSyncing || refreshed.LatestSyncJobStatus != types.JobFailed {
+		t.Fatalf("expected the failed sync job, got %+v", refreshed)
+	}
+
+	transforms := refreshed.CatalogDiff.Transforms
+	if len(transforms) != 1 || transforms[0].TransformType != types.AddStream || transforms[0].StreamDescriptor.Name != "pokemon" {
+		t.Fatalf("expected the pokemon stream to be added, got %+v", transforms)
+	}
+
+	update := &types.WebBackendConnectionUpdate{Connection: refreshed.Connection, SkipReset: true}
+	update.Name = "renamed"
+
+	updated, err := airbyte.UpdateWebBackendConnection(ctx, update)
+	if err != nil {
+		t.Fatalf("could not update connection: %v", err)
+	}
+
+	if updated.Name != "renamed" || len(updated.Operations) != 0 {
+		t.Fatalf("unexpected updated connection: %+v", updated)
+	}
+}
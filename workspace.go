@@ -59,6 +59,11 @@ func (c *Client) ListWorkspaces(ctx context.Context) ([]types.Workspace, error)
 	return types.WorkspacesFromJSON(res.Body)
 }
 
+// IterateWorkspaces returns an iterator over all workspaces that decodes them one at a time
+func (c *Client) IterateWorkspaces(ctx context.Context) *WorkspaceIterator {
+	return &WorkspaceIterator{listIterator: c.newListIterator(ctx, "/v1/workspaces/list", nil, "workspaces")}
+}
+
 // FindWorkspaceByID returns the workspace with the given ID
 func (c *Client) FindWorkspaceByID(ctx context.Context, id *uuid.UUID) (*types.Workspace, error) {
 	u, err := appendToURL(c.endpoint, "/v1/workspaces/get")
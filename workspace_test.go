@@ -4,11 +4,15 @@ import (
 	"context"
 	"testing"
 
+	"github.com/evris99/airbyte-sdk/airbytetest"
 	"github.com/evris99/airbyte-sdk/types"
 )
 
 func TestListWorkspace(t *testing.T) {
-	airbyte, err := New("http://localhost:8000/api")
+	srv := airbytetest.NewServer()
+	defer srv.Close()
+
+	airbyte, err := New(srv.Endpoint())
 	if err != nil {
 		t.Fatalf("could not create instance: %v", err)
 	}
@@ -51,7 +55,10 @@ func TestListWorkspace(t *testing.T) {
 }
 
 func TestFindWorkspace(t *testing.T) {
-	airbyte, err := New("http://localhost:8000/api")
+	srv := airbytetest.NewServer()
+	defer srv.Close()
+
+	airbyte, err := New(srv.Endpoint())
 	if err != nil {
 		t.Fatalf("could not create instance: %v", err)
 	}
@@ -95,7 +102,10 @@ func TestFindWorkspace(t *testing.T) {
 }
 
 func TestUpdateWorkspace(t *testing.T) {
-	airbyte, err := New("http://localhost:8000/api")
+	srv := airbytetest.NewServer()
+	defer srv.Close()
+
+	airbyte, err := New(srv.Endpoint())
 	if err != nil {
 		t.Fatalf("could not create instance: %v", err)
 	}